@@ -1,10 +1,23 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 )
 
 // ProviderType represents the type of LLM provider
@@ -24,7 +37,43 @@ type Provider struct {
 	Description string            `json:"description"`
 	Enabled     bool              `json:"enabled"`
 	Available   bool              `json:"available"` // Runtime availability check
-	Config      map[string]string `json:"config"`    // Provider-specific config
+	Config      map[string]string `json:"config"`    // Provider-specific config, e.g. model, models, api_key, base_url, max_tokens, anthropic_version, header.<name>
+}
+
+// Models returns the comma-separated models listed under the "models" config
+// key, which the provider view cycles the model through
+func (p *Provider) Models() []string {
+	var models []string
+	for _, model := range strings.Split(p.Config["models"], ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// CycleModel moves the provider's model step places through its models list,
+// wrapping around at either end. A model that isn't in the list moves to the
+// first model, or the last going backwards. Returns false when the provider
+// has no models list.
+func (p *Provider) CycleModel(step int) bool {
+	models := p.Models()
+	if len(models) == 0 {
+		return false
+	}
+
+	next := 0
+	if step < 0 {
+		next = len(models) - 1
+	}
+	for i, model := range models {
+		if model == p.Config["model"] {
+			next = ((i+step)%len(models) + len(models)) % len(models)
+			break
+		}
+	}
+	p.Config["model"] = models[next]
+	return true
 }
 
 // ProviderConfig manages the configuration of all LLM providers
@@ -45,8 +94,11 @@ func DefaultProviderConfig() *ProviderConfig {
 				Enabled:     true,
 				Available:   false, // Will be checked at runtime
 				Config: map[string]string{
-					"model":   "claude-3-5-sonnet-20241022",
-					"api_key": "ANTHROPIC_API_KEY", // Environment variable name
+					"model":             llm.DefaultClaudeModel,
+					"models":            strings.Join([]string{llm.DefaultClaudeModel, "claude-3-5-haiku-20241022", "claude-3-opus-20240229"}, ","),
+					"api_key":           "ANTHROPIC_API_KEY", // Environment variable name
+					"anthropic_version": llm.DefaultAnthropicVersion,
+					"max_tokens":        strconv.Itoa(llm.DefaultMaxTokens),
 				},
 			},
 			{
@@ -66,8 +118,24 @@ func DefaultProviderConfig() *ProviderConfig {
 				Enabled:     true, // Now implemented
 				Available:   false,
 				Config: map[string]string{
-					"model":   "gpt-3.5-turbo",
-					"api_key": "OPENAI_API_KEY",
+					"model":      llm.DefaultOpenAIModel,
+					"models":     strings.Join([]string{llm.DefaultOpenAIModel, "gpt-4o-mini", "gpt-4o", "gpt-4-turbo"}, ","),
+					"api_key":    "OPENAI_API_KEY",
+					"max_tokens": strconv.Itoa(llm.DefaultMaxTokens),
+				},
+			},
+			{
+				ID:          "openai-compatible",
+				Name:        "OpenAI-compatible API",
+				Type:        APIProviderType,
+				Description: "Any OpenAI-compatible endpoint, e.g. Groq, Together or LM Studio (set base_url and model)",
+				Enabled:     true,
+				Available:   false, // Available once base_url and model are configured
+				Config: map[string]string{
+					"base_url":   "",
+					"model":      "",
+					"api_key":    "", // Environment variable name, empty for servers without auth
+					"max_tokens": strconv.Itoa(llm.DefaultMaxTokens),
 				},
 			},
 			{
@@ -95,45 +163,194 @@ func DefaultProviderConfig() *ProviderConfig {
 				},
 			},
 		},
-		ActiveProviderID: "claude-cli", // Default to Claude CLI
+		ActiveProviderID: "claude-cli", // Default to Claude CLI, see SelectDefaultActiveProvider
 	}
 }
 
-// LoadProviderConfig returns the default provider configuration
+// defaultProviderTypePreference is the order in which provider types are
+// considered when picking a default active provider
+var defaultProviderTypePreference = []ProviderType{
+	CLIProviderType,
+	APIProviderType,
+	LocalProviderType,
+}
+
+// SelectDefaultActiveProvider sets the active provider to the first enabled
+// provider that is available in the current environment, preferring CLI tools,
+// then API keys, then local services. Returns false and leaves the active
+// provider untouched when nothing is available, in which case callers fall
+// back to the mock provider.
+func SelectDefaultActiveProvider(config *ProviderConfig) bool {
+	logger := core.GetLogger()
+
+	for _, providerType := range defaultProviderTypePreference {
+		for i := range config.Providers {
+			provider := &config.Providers[i]
+			if provider.Type != providerType || !provider.Enabled {
+				continue
+			}
+
+			if CheckProviderAvailability(provider) {
+				config.ActiveProviderID = provider.ID
+				logger.Info("Selected default active provider", "provider_id", provider.ID)
+				return true
+			}
+		}
+	}
+
+	logger.Warn("No available provider found for default selection", "active_provider_id", config.ActiveProviderID)
+	return false
+}
+
+// providerConfigFile is the name of the saved provider configuration in the data directory
+const providerConfigFile = "providers.json"
+
+// ProviderConfigPath returns the location of the saved provider configuration,
+// providers.json in the data directory (~/.commitlore by default)
+func ProviderConfigPath() (string, error) {
+	dataDir, err := core.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, providerConfigFile), nil
+}
+
+// LoadProviderConfig reads the saved provider configuration and merges it over
+// the defaults. A missing or corrupt file falls back to the defaults, and the
+// saved active provider is kept as long as it still exists, otherwise one is
+// picked with SelectDefaultActiveProvider.
 func LoadProviderConfig() (*ProviderConfig, error) {
 	logger := core.GetLogger()
-	logger.Debug("Loading default provider configuration")
+	logger.Debug("Loading provider configuration")
 
 	config := DefaultProviderConfig()
-	logger.Info("Successfully loaded default provider config", "providers_count", len(config.Providers))
+
+	saved, err := readProviderConfig()
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("Ignoring unreadable provider config, using defaults", "error", err)
+		}
+		SelectDefaultActiveProvider(config)
+		logger.Info("Successfully loaded default provider config", "providers_count", len(config.Providers), "active_provider_id", config.ActiveProviderID)
+		return config, nil
+	}
+
+	mergeProviderConfig(config, saved)
+	if GetProviderByID(config, saved.ActiveProviderID) != nil {
+		config.ActiveProviderID = saved.ActiveProviderID
+	} else {
+		logger.Warn("Saved active provider no longer exists, selecting a default", "active_provider_id", saved.ActiveProviderID)
+		SelectDefaultActiveProvider(config)
+	}
+
+	logger.Info("Successfully loaded saved provider config", "providers_count", len(config.Providers), "active_provider_id", config.ActiveProviderID)
 	return config, nil
 }
 
-// SaveProviderConfig is a no-op since we don't persist configuration
+// readProviderConfig unmarshals the saved provider configuration
+func readProviderConfig() (*ProviderConfig, error) {
+	path, err := ProviderConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved ProviderConfig
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &saved, nil
+}
+
+// mergeProviderConfig overlays the saved providers onto the defaults. Saved
+// settings win, config keys and providers added in newer versions are kept,
+// and providers that only exist in the saved file are appended.
+func mergeProviderConfig(config *ProviderConfig, saved *ProviderConfig) {
+	for _, savedProvider := range saved.Providers {
+		provider := GetProviderByID(config, savedProvider.ID)
+		if provider == nil {
+			savedProvider.Available = false
+			if savedProvider.Config == nil {
+				savedProvider.Config = map[string]string{}
+			}
+			config.Providers = append(config.Providers, savedProvider)
+			continue
+		}
+
+		provider.Enabled = savedProvider.Enabled
+		for key, value := range savedProvider.Config {
+			provider.Config[key] = value
+		}
+	}
+}
+
+// SaveProviderConfig writes the provider configuration to providers.json in
+// the data directory, creating the directory if needed
 func SaveProviderConfig(config *ProviderConfig) error {
 	logger := core.GetLogger()
-	logger.Debug("Provider configuration is not persisted to disk")
+
+	path, err := ProviderConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate provider config: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode provider config: %w", err)
+	}
+
+	// Write to a temporary file first so an interrupted save can't leave a
+	// truncated config behind
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write provider config: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save provider config: %w", err)
+	}
+
+	logger.Info("Saved provider config", "path", path, "active_provider_id", config.ActiveProviderID)
 	return nil
 }
 
-// CheckProviderAvailability checks if a provider is available at runtime
+// AvailabilityCheckTimeout bounds how long a single provider availability
+// check may take, so a hanging local service can't stall the UI
+const AvailabilityCheckTimeout = 2 * time.Second
+
+// CheckProviderAvailability checks if a provider is available at runtime,
+// giving up after AvailabilityCheckTimeout
 func CheckProviderAvailability(provider *Provider) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), AvailabilityCheckTimeout)
+	defer cancel()
+	return CheckProviderAvailabilityContext(ctx, provider)
+}
+
+// CheckProviderAvailabilityContext checks if a provider is available at
+// runtime. ctx bounds checks that have to reach a service, such as a local
+// model server.
+func CheckProviderAvailabilityContext(ctx context.Context, provider *Provider) bool {
 	logger := core.GetLogger()
 	logger.Debug("Checking provider availability", "provider_id", provider.ID, "type", provider.Type)
 
 	switch provider.Type {
 	case APIProviderType:
-		// Check if API key environment variable is set
-		if envVar, exists := provider.Config["api_key"]; exists {
-			apiKey := os.Getenv(envVar)
-			available := apiKey != ""
-			logger.Debug("API provider availability check",
-				"provider_id", provider.ID,
-				"env_var", envVar,
-				"available", available)
-			return available
+		// Check if the API key file or environment variable is set
+		_, err := apiKeyFromConfig(provider)
+		if provider.ID == "openai-compatible" {
+			_, _, err = openAICompatibleSettings(provider)
 		}
-		return false
+		available := err == nil
+		logger.Debug("API provider availability check",
+			"provider_id", provider.ID,
+			"env_var", provider.Config["api_key"],
+			"key_file", provider.Config["api_key_file"],
+			"available", available)
+		return available
 
 	case CLIProviderType:
 		// Check if CLI tool is available in PATH
@@ -152,11 +369,12 @@ func CheckProviderAvailability(provider *Provider) bool {
 		// Check if local service is running (e.g., Ollama)
 		switch provider.ID {
 		case "ollama":
-			// TODO: Implement Ollama availability check via HTTP ping
+			available := pingOllama(ctx, provider.Config["endpoint"])
 			logger.Debug("Local provider availability check",
 				"provider_id", provider.ID,
-				"available", false)
-			return false
+				"endpoint", provider.Config["endpoint"],
+				"available", available)
+			return available
 		}
 		return false
 
@@ -166,16 +384,58 @@ func CheckProviderAvailability(provider *Provider) bool {
 	}
 }
 
-// UpdateProviderAvailability updates the availability status of all providers
+// pingOllama reports whether an Ollama server answers at endpoint, by listing
+// its models. A server that isn't running is expected, so failures are only
+// logged at debug level.
+func pingOllama(ctx context.Context, endpoint string) bool {
+	if endpoint == "" {
+		return false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/api/tags", nil)
+	if err != nil {
+		core.GetLogger().Debug("Invalid Ollama endpoint", "endpoint", endpoint, "error", err)
+		return false
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			core.GetLogger().Debug("Ollama is not running", "endpoint", endpoint)
+		} else {
+			core.GetLogger().Debug("Failed to reach Ollama", "endpoint", endpoint, "error", err)
+		}
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		core.GetLogger().Debug("Ollama returned an unexpected status", "endpoint", endpoint, "status", resp.StatusCode)
+		return false
+	}
+	return true
+}
+
+// UpdateProviderAvailability updates the availability status of all
+// providers, checking them concurrently
 func UpdateProviderAvailability(config *ProviderConfig) {
 	logger := core.GetLogger()
 	logger.Debug("Updating provider availability for all providers")
 
+	var wg sync.WaitGroup
 	for i := range config.Providers {
-		config.Providers[i].Available = CheckProviderAvailability(&config.Providers[i])
+		wg.Add(1)
+		go func(provider *Provider) {
+			defer wg.Done()
+			provider.Available = CheckProviderAvailability(provider)
+		}(&config.Providers[i])
+	}
+	wg.Wait()
+
+	for _, provider := range config.Providers {
 		logger.Debug("Provider availability updated",
-			"provider_id", config.Providers[i].ID,
-			"available", config.Providers[i].Available)
+			"provider_id", provider.ID,
+			"available", provider.Available)
 	}
 }
 
@@ -1,13 +1,32 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 )
 
+// ErrProviderNotImplemented is returned for providers that are listed in the
+// configuration but can't be created yet
+var ErrProviderNotImplemented = errors.New("provider not yet implemented")
+
+// IsImplemented reports whether the factory can create the provider with the
+// given ID, so that providers it can't are never enabled or made active
+func IsImplemented(providerID string) bool {
+	switch providerID {
+	case "gemini-api", "ollama":
+		return false
+	}
+	return true
+}
+
 // ProviderFactory creates LLM provider instances based on configuration
 type ProviderFactory struct {
 	config *ProviderConfig
@@ -21,63 +40,63 @@ func NewProviderFactory(config *ProviderConfig) *ProviderFactory {
 }
 
 // CreateActiveProvider creates an instance of the currently active provider
-func (f *ProviderFactory) CreateActiveProvider() (llm.LLMProvider, string, error) {
+func (f *ProviderFactory) CreateActiveProvider() (llm.LLMProvider, error) {
 	logger := core.GetLogger()
 	
 	activeProvider := GetProviderByID(f.config, f.config.ActiveProviderID)
 	if activeProvider == nil {
 		logger.Error("Active provider not found", "provider_id", f.config.ActiveProviderID)
-		return nil, "", fmt.Errorf("active provider '%s' not found", f.config.ActiveProviderID)
+		return nil, fmt.Errorf("active provider '%s' not found", f.config.ActiveProviderID)
 	}
 
 	if !activeProvider.Enabled {
 		logger.Error("Active provider is disabled", "provider_id", activeProvider.ID)
-		return nil, "", fmt.Errorf("active provider '%s' is disabled", activeProvider.ID)
+		return nil, fmt.Errorf("active provider '%s' is disabled", activeProvider.ID)
 	}
 
 	if !CheckProviderAvailability(activeProvider) {
 		logger.Error("Active provider is not available", "provider_id", activeProvider.ID)
-		return nil, "", fmt.Errorf("active provider '%s' is not available", activeProvider.ID)
+		return nil, fmt.Errorf("active provider '%s' is not available", activeProvider.ID)
 	}
 
 	provider, err := f.createProvider(activeProvider)
 	if err != nil {
 		logger.Error("Failed to create active provider", "provider_id", activeProvider.ID, "error", err)
-		return nil, "", fmt.Errorf("failed to create provider '%s': %w", activeProvider.ID, err)
+		return nil, fmt.Errorf("failed to create provider '%s': %w", activeProvider.ID, err)
 	}
 
 	logger.Info("Successfully created active provider", "provider_id", activeProvider.ID, "provider_name", activeProvider.Name)
-	return provider, activeProvider.Name, nil
+	return provider, nil
 }
 
 // CreateProvider creates an instance of a specific provider by ID
-func (f *ProviderFactory) CreateProvider(providerID string) (llm.LLMProvider, string, error) {
+func (f *ProviderFactory) CreateProvider(providerID string) (llm.LLMProvider, error) {
 	logger := core.GetLogger()
 	
 	provider := GetProviderByID(f.config, providerID)
 	if provider == nil {
 		logger.Error("Provider not found", "provider_id", providerID)
-		return nil, "", fmt.Errorf("provider '%s' not found", providerID)
+		return nil, fmt.Errorf("provider '%s' not found", providerID)
 	}
 
 	if !provider.Enabled {
 		logger.Error("Provider is disabled", "provider_id", provider.ID)
-		return nil, "", fmt.Errorf("provider '%s' is disabled", provider.ID)
+		return nil, fmt.Errorf("provider '%s' is disabled", provider.ID)
 	}
 
 	if !CheckProviderAvailability(provider) {
 		logger.Error("Provider is not available", "provider_id", provider.ID)
-		return nil, "", fmt.Errorf("provider '%s' is not available", provider.ID)
+		return nil, fmt.Errorf("provider '%s' is not available", provider.ID)
 	}
 
 	llmProvider, err := f.createProvider(provider)
 	if err != nil {
 		logger.Error("Failed to create provider", "provider_id", provider.ID, "error", err)
-		return nil, "", fmt.Errorf("failed to create provider '%s': %w", provider.ID, err)
+		return nil, fmt.Errorf("failed to create provider '%s': %w", provider.ID, err)
 	}
 
 	logger.Info("Successfully created provider", "provider_id", provider.ID, "provider_name", provider.Name)
-	return llmProvider, provider.Name, nil
+	return llmProvider, nil
 }
 
 // createProvider creates the actual provider instance based on its configuration
@@ -104,36 +123,57 @@ func (f *ProviderFactory) createAPIProvider(provider *Provider) (llm.LLMProvider
 
 	switch provider.ID {
 	case "claude-api":
-		envVar, exists := provider.Config["api_key"]
-		if !exists {
-			return nil, fmt.Errorf("API key environment variable not configured")
-		}
-
-		apiKey := os.Getenv(envVar)
-		if apiKey == "" {
-			return nil, fmt.Errorf("API key not found in environment variable %s", envVar)
+		apiKey, err := apiKeyFromConfig(provider)
+		if err != nil {
+			return nil, err
 		}
 
-		logger.Info("Creating Claude API client", "model", provider.Config["model"])
-		return llm.NewClaudeClient(apiKey), nil
+		logger.Info("Creating Claude API client", "model", provider.Config["model"], "base_url", provider.Config["base_url"])
+		return llm.NewClaudeClientWithOptions(apiKey, llm.ClaudeClientOptions{
+			Model:       provider.Config["model"],
+			BaseURL:     provider.Config["base_url"],
+			APIVersion:  provider.Config["anthropic_version"],
+			Headers:     headersFromConfig(provider.Config),
+			MaxTokens:   maxTokensFromConfig(provider),
+			Temperature: temperatureFromConfig(provider),
+			Timeout:     timeoutFromConfig(provider),
+		}), nil
 
 	case "openai-api":
-		envVar, exists := provider.Config["api_key"]
-		if !exists {
-			return nil, fmt.Errorf("API key environment variable not configured")
+		apiKey, err := apiKeyFromConfig(provider)
+		if err != nil {
+			return nil, err
 		}
 
-		apiKey := os.Getenv(envVar)
-		if apiKey == "" {
-			return nil, fmt.Errorf("API key not found in environment variable %s", envVar)
+		logger.Info("Creating OpenAI API client", "model", provider.Config["model"], "base_url", provider.Config["base_url"])
+		return llm.NewOpenAIClientWithOptions(apiKey, llm.OpenAIClientOptions{
+			BaseURL:     provider.Config["base_url"],
+			Model:       provider.Config["model"],
+			MaxTokens:   maxTokensFromConfig(provider),
+			Temperature: temperatureFromConfig(provider),
+			Timeout:     timeoutFromConfig(provider),
+		}), nil
+
+	case "openai-compatible":
+		apiKey, model, err := openAICompatibleSettings(provider)
+		if err != nil {
+			return nil, err
 		}
 
-		logger.Info("Creating OpenAI API client", "model", provider.Config["model"])
-		return llm.NewOpenAIClient(apiKey), nil
+		logger.Info("Creating OpenAI-compatible API client", "model", model, "base_url", provider.Config["base_url"])
+		return llm.NewOpenAIClientWithOptions(apiKey, llm.OpenAIClientOptions{
+			BaseURL:     provider.Config["base_url"],
+			Model:       model,
+			Name:        provider.Name,
+			Headers:     headersFromConfig(provider.Config),
+			MaxTokens:   maxTokensFromConfig(provider),
+			Temperature: temperatureFromConfig(provider),
+			Timeout:     timeoutFromConfig(provider),
+		}), nil
 
 	case "gemini-api":
 		// TODO: Implement Gemini API provider
-		return nil, fmt.Errorf("Gemini API provider not yet implemented")
+		return nil, fmt.Errorf("Gemini API: %w", ErrProviderNotImplemented)
 
 	default:
 		return nil, fmt.Errorf("unsupported API provider: %s", provider.ID)
@@ -163,7 +203,7 @@ func (f *ProviderFactory) createLocalProvider(provider *Provider) (llm.LLMProvid
 	switch provider.ID {
 	case "ollama":
 		// TODO: Implement Ollama provider
-		return nil, fmt.Errorf("Ollama provider not yet implemented")
+		return nil, fmt.Errorf("Ollama: %w", ErrProviderNotImplemented)
 
 	default:
 		return nil, fmt.Errorf("unsupported local provider: %s", provider.ID)
@@ -201,4 +241,154 @@ func (f *ProviderFactory) SetActiveProvider(providerID string) error {
 	f.config.ActiveProviderID = providerID
 	logger.Info("Successfully set active provider", "provider_id", providerID, "provider_name", provider.Name)
 	return nil
-}
\ No newline at end of file
+}
+
+// apiKeyFromConfig returns a provider's API key. The trimmed contents of the
+// file named by "api_key_file" take precedence over the environment variable
+// named by "api_key".
+func apiKeyFromConfig(provider *Provider) (string, error) {
+	if path := provider.Config["api_key_file"]; path != "" {
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			if homeDir, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(homeDir, rest)
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read API key file: %w", err)
+		}
+		apiKey := strings.TrimSpace(string(data))
+		if apiKey == "" {
+			return "", fmt.Errorf("API key file %s is empty", path)
+		}
+		return apiKey, nil
+	}
+
+	envVar, exists := provider.Config["api_key"]
+	if !exists {
+		return "", fmt.Errorf("API key environment variable not configured")
+	}
+
+	apiKey := os.Getenv(envVar)
+	if apiKey == "" {
+		return "", fmt.Errorf("API key not found in environment variable %s", envVar)
+	}
+	return apiKey, nil
+}
+
+// openAICompatibleSettings returns the API key and model of an
+// OpenAI-compatible provider. base_url and model are required since there is
+// no sensible default; the API key is optional, for servers without auth.
+func openAICompatibleSettings(provider *Provider) (string, string, error) {
+	if provider.Config["base_url"] == "" {
+		return "", "", fmt.Errorf("base_url not configured for %s", provider.ID)
+	}
+	model := provider.Config["model"]
+	if model == "" {
+		return "", "", fmt.Errorf("model not configured for %s", provider.ID)
+	}
+
+	if provider.Config["api_key"] == "" && provider.Config["api_key_file"] == "" {
+		return "", model, nil
+	}
+	apiKey, err := apiKeyFromConfig(provider)
+	if err != nil {
+		return "", "", err
+	}
+	return apiKey, model, nil
+}
+
+// headerConfigPrefix marks provider config keys that are sent as extra HTTP
+// headers, e.g. "header.anthropic-beta"
+const headerConfigPrefix = "header."
+
+// reservedHeaders are set by the clients themselves and can't be overridden
+// from a provider's config, so a stray header can't replace the credentials
+var reservedHeaders = []string{"x-api-key", "anthropic-version", "Authorization", "Content-Type"}
+
+// headersFromConfig collects the extra HTTP headers set in a provider's config,
+// skipping reserved headers
+func headersFromConfig(config map[string]string) map[string]string {
+	headers := make(map[string]string)
+	for key, value := range config {
+		name, ok := strings.CutPrefix(key, headerConfigPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		if isReservedHeader(name) {
+			core.GetLogger().Warn("Ignoring reserved header in provider config", "header", name)
+			continue
+		}
+		headers[name] = value
+	}
+	return headers
+}
+
+// isReservedHeader reports whether name is one of reservedHeaders, ignoring
+// case as HTTP does
+func isReservedHeader(name string) bool {
+	for _, reserved := range reservedHeaders {
+		if strings.EqualFold(name, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxTokensFromConfig reads the max_tokens setting of a provider, falling back
+// to llm.DefaultMaxTokens when it is missing or not a positive number
+func maxTokensFromConfig(provider *Provider) int {
+	value, exists := provider.Config["max_tokens"]
+	if !exists || value == "" {
+		return llm.DefaultMaxTokens
+	}
+
+	maxTokens, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || maxTokens <= 0 {
+		core.GetLogger().Warn("Invalid max_tokens in provider config, using default",
+			"provider_id", provider.ID,
+			"max_tokens", value,
+			"default", llm.DefaultMaxTokens)
+		return llm.DefaultMaxTokens
+	}
+	return maxTokens
+}
+
+// temperatureFromConfig reads the temperature setting of a provider. It
+// returns nil, leaving the client's default, when the setting is missing or
+// outside llm.MinTemperature and llm.MaxTemperature.
+func temperatureFromConfig(provider *Provider) *float64 {
+	value, exists := provider.Config["temperature"]
+	if !exists || value == "" {
+		return nil
+	}
+
+	temperature, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || temperature < llm.MinTemperature || temperature > llm.MaxTemperature {
+		core.GetLogger().Warn("Invalid temperature in provider config, using the provider default",
+			"provider_id", provider.ID,
+			"temperature", value)
+		return nil
+	}
+	return &temperature
+}
+
+// timeoutFromConfig reads the timeout setting of a provider, a duration such
+// as "90s" capping each HTTP request. It returns zero, leaving cancellation to
+// the request context, when the setting is missing or invalid.
+func timeoutFromConfig(provider *Provider) time.Duration {
+	value, exists := provider.Config["timeout"]
+	if !exists || value == "" {
+		return 0
+	}
+
+	timeout, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || timeout < 0 {
+		core.GetLogger().Warn("Invalid timeout in provider config, relying on the request deadline",
+			"provider_id", provider.ID,
+			"timeout", value)
+		return 0
+	}
+	return timeout
+}
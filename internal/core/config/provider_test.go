@@ -0,0 +1,361 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
+)
+
+func TestMain(m *testing.M) {
+	dataDir, err := os.MkdirTemp("", "commitlore-config-test-*")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("COMMITLORE_HOME", dataDir)
+
+	if err := core.InitLogger(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dataDir)
+	os.Exit(code)
+}
+
+func TestProviderConfigRoundTrip(t *testing.T) {
+	t.Setenv("COMMITLORE_HOME", t.TempDir())
+
+	saved := DefaultProviderConfig()
+	saved.ActiveProviderID = "openai-api"
+	GetProviderByID(saved, "openai-api").Config["model"] = "gpt-4o"
+	// Simulate a config saved before a provider existed
+	saved.Providers = saved.Providers[:len(saved.Providers)-1]
+
+	if err := SaveProviderConfig(saved); err != nil {
+		t.Fatalf("SaveProviderConfig failed: %v", err)
+	}
+
+	loaded, err := LoadProviderConfig()
+	if err != nil {
+		t.Fatalf("LoadProviderConfig failed: %v", err)
+	}
+
+	if loaded.ActiveProviderID != "openai-api" {
+		t.Errorf("Expected saved active provider to be kept, got %s", loaded.ActiveProviderID)
+	}
+	if model := GetProviderByID(loaded, "openai-api").Config["model"]; model != "gpt-4o" {
+		t.Errorf("Expected saved model gpt-4o, got %s", model)
+	}
+	if len(loaded.Providers) != len(DefaultProviderConfig().Providers) {
+		t.Errorf("Expected new default providers to be merged in, got %d providers", len(loaded.Providers))
+	}
+}
+
+func TestCycleModel(t *testing.T) {
+	provider := &Provider{Config: map[string]string{"model": "b", "models": "a, b,c"}}
+
+	steps := []struct {
+		step     int
+		expected string
+	}{
+		{1, "c"},
+		{1, "a"},
+		{-1, "c"},
+		{-2, "a"},
+	}
+	for _, s := range steps {
+		if !provider.CycleModel(s.step) || provider.Config["model"] != s.expected {
+			t.Fatalf("Expected %q after a step of %d, got %q", s.expected, s.step, provider.Config["model"])
+		}
+	}
+
+	// A model outside the list starts from either end
+	provider.Config["model"] = "custom"
+	if provider.CycleModel(-1); provider.Config["model"] != "c" {
+		t.Errorf("Expected the last model, got %q", provider.Config["model"])
+	}
+
+	empty := &Provider{Config: map[string]string{"model": "x"}}
+	if empty.CycleModel(1) || empty.Config["model"] != "x" {
+		t.Errorf("Expected no change without a models list, got %q", empty.Config["model"])
+	}
+}
+
+func TestLoadProviderConfigCorruptFile(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("COMMITLORE_HOME", dataDir)
+
+	if err := os.WriteFile(filepath.Join(dataDir, providerConfigFile), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loaded, err := LoadProviderConfig()
+	if err != nil {
+		t.Fatalf("Expected corrupt config to fall back to defaults, got error: %v", err)
+	}
+	if len(loaded.Providers) != len(DefaultProviderConfig().Providers) {
+		t.Errorf("Expected default providers, got %d", len(loaded.Providers))
+	}
+}
+
+func TestMaxTokensFromConfig(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", llm.DefaultMaxTokens},
+		{"8192", 8192},
+		{" 1000 ", 1000},
+		{"lots", llm.DefaultMaxTokens},
+		{"-5", llm.DefaultMaxTokens},
+		{"0", llm.DefaultMaxTokens},
+	}
+
+	for _, test := range tests {
+		provider := &Provider{ID: "claude-api", Config: map[string]string{"max_tokens": test.value}}
+		if got := maxTokensFromConfig(provider); got != test.expected {
+			t.Errorf("maxTokensFromConfig(%q) = %d, expected %d", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestTemperatureFromConfig(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected *float64
+	}{
+		{"", nil},
+		{"0", ptr(0.0)},
+		{" 0.2 ", ptr(0.2)},
+		{"1", ptr(1.0)},
+		{"warm", nil},
+		{"1.5", nil},
+		{"-0.1", nil},
+	}
+
+	for _, test := range tests {
+		provider := &Provider{ID: "claude-api", Config: map[string]string{"temperature": test.value}}
+		got := temperatureFromConfig(provider)
+		if (got == nil) != (test.expected == nil) || (got != nil && *got != *test.expected) {
+			t.Errorf("temperatureFromConfig(%q) = %v, expected %v", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestTimeoutFromConfig(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"90s", 90 * time.Second},
+		{" 3m ", 3 * time.Minute},
+		{"0", 0},
+		{"soon", 0},
+		{"-5s", 0},
+	}
+
+	for _, test := range tests {
+		provider := &Provider{ID: "openai-api", Config: map[string]string{"timeout": test.value}}
+		if got := timeoutFromConfig(provider); got != test.expected {
+			t.Errorf("timeoutFromConfig(%q) = %v, expected %v", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestHeadersFromConfig(t *testing.T) {
+	headers := headersFromConfig(map[string]string{
+		"model":                    "claude-sonnet-4-20250514",
+		"header.anthropic-beta":    "prompt-caching-2024-07-31",
+		"header.X-Title":           "CommitLore",
+		"header.x-api-key":         "other-key",
+		"header.Anthropic-Version": "2020-01-01",
+		"header.authorization":     "Bearer other-key",
+		"header.Content-Type":      "text/plain",
+		"header.":                  "empty",
+	})
+
+	expected := map[string]string{
+		"anthropic-beta": "prompt-caching-2024-07-31",
+		"X-Title":        "CommitLore",
+	}
+	if len(headers) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, headers)
+	}
+	for name, value := range expected {
+		if headers[name] != value {
+			t.Errorf("Expected header %s to be %q, got %q", name, value, headers[name])
+		}
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestUpdateProviderAvailability(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	providerConfig := DefaultProviderConfig()
+	UpdateProviderAvailability(providerConfig)
+
+	if !GetProviderByID(providerConfig, "claude-api").Available {
+		t.Error("Expected claude-api to be available with ANTHROPIC_API_KEY set")
+	}
+	if GetProviderByID(providerConfig, "openai-api").Available {
+		t.Error("Expected openai-api to be unavailable without OPENAI_API_KEY")
+	}
+}
+
+func TestOllamaAvailability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"models":[]}`))
+	}))
+
+	provider := GetProviderByID(DefaultProviderConfig(), "ollama")
+	provider.Config["endpoint"] = server.URL + "/"
+	if !CheckProviderAvailability(provider) {
+		t.Error("Expected Ollama to be available while the server is running")
+	}
+
+	server.Close()
+	if CheckProviderAvailability(provider) {
+		t.Error("Expected Ollama to be unavailable once the server is stopped")
+	}
+}
+
+func TestAPIKeyFromConfig(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "env-key")
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	provider := &Provider{ID: "claude-api", Type: APIProviderType, Config: map[string]string{"api_key": "TEST_API_KEY"}}
+	if key, err := apiKeyFromConfig(provider); err != nil || key != "env-key" {
+		t.Errorf("Expected the environment variable's key, got %q (%v)", key, err)
+	}
+
+	provider.Config["api_key_file"] = keyFile
+	if key, err := apiKeyFromConfig(provider); err != nil || key != "file-key" {
+		t.Errorf("Expected the trimmed key file contents, got %q (%v)", key, err)
+	}
+
+	provider.Config["api_key_file"] = filepath.Join(t.TempDir(), "missing")
+	if _, err := apiKeyFromConfig(provider); err == nil {
+		t.Error("Expected an error for a missing key file")
+	}
+	if CheckProviderAvailability(provider) {
+		t.Error("Expected the provider to be unavailable with a missing key file")
+	}
+}
+
+func TestOpenAIProviderUsesConfiguredModel(t *testing.T) {
+	var gotModel string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req llm.OpenAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		w.Write([]byte(`{"id":"1","choices":[{"message":{"role":"assistant","content":"hello"}}]}`))
+	}))
+	defer server.Close()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	config := DefaultProviderConfig()
+	provider := GetProviderByID(config, "openai-api")
+	provider.Config["model"] = "gpt-4o"
+	provider.Config["base_url"] = server.URL + "/v1"
+
+	client, err := NewProviderFactory(config).CreateProvider("openai-api")
+	if err != nil {
+		t.Fatalf("CreateProvider failed: %v", err)
+	}
+	if _, model := client.ModelInfo(); model != "gpt-4o" {
+		t.Errorf("Expected the configured model gpt-4o, got %q", model)
+	}
+	if _, err := client.GenerateContent(context.Background(), "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if gotModel != "gpt-4o" {
+		t.Errorf("Expected gpt-4o in the request, got %q", gotModel)
+	}
+}
+
+func TestOpenAICompatibleProvider(t *testing.T) {
+	var gotModel, gotAuth, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req llm.OpenAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		gotAuth = r.Header.Get("Authorization")
+		gotHeader = r.Header.Get("X-Title")
+		w.Write([]byte(`{"id":"1","choices":[{"message":{"role":"assistant","content":"hello"}}]}`))
+	}))
+	defer server.Close()
+
+	config := DefaultProviderConfig()
+	provider := GetProviderByID(config, "openai-compatible")
+	if CheckProviderAvailability(provider) {
+		t.Error("Expected the provider to be unavailable without a base_url and model")
+	}
+
+	provider.Config["base_url"] = server.URL + "/v1"
+	provider.Config["model"] = "llama-3.1-8b-instant"
+	provider.Config["header.X-Title"] = "CommitLore"
+	if !CheckProviderAvailability(provider) {
+		t.Fatal("Expected the provider to be available without an API key")
+	}
+
+	client, err := NewProviderFactory(config).CreateProvider("openai-compatible")
+	if err != nil {
+		t.Fatalf("CreateProvider failed: %v", err)
+	}
+	if providerName, model := client.ModelInfo(); providerName != provider.Name || model != "llama-3.1-8b-instant" {
+		t.Errorf("Unexpected model info %q %q", providerName, model)
+	}
+
+	content, err := client.GenerateContent(context.Background(), "prompt")
+	if err != nil || content != "hello" {
+		t.Fatalf("Expected the server's response, got %q (%v)", content, err)
+	}
+	if gotModel != "llama-3.1-8b-instant" || gotAuth != "" || gotHeader != "CommitLore" {
+		t.Errorf("Unexpected request: model %q, auth %q, header %q", gotModel, gotAuth, gotHeader)
+	}
+
+	// A configured key that can't be found makes the provider unavailable
+	provider.Config["api_key"] = "MISSING_TEST_API_KEY"
+	if CheckProviderAvailability(provider) {
+		t.Error("Expected the provider to be unavailable with a missing API key")
+	}
+}
+
+func TestUnimplementedProviders(t *testing.T) {
+	config := DefaultProviderConfig()
+	factory := NewProviderFactory(config)
+	for _, provider := range config.Providers {
+		_, err := factory.createProvider(GetProviderByID(config, provider.ID))
+		if IsImplemented(provider.ID) {
+			if errors.Is(err, ErrProviderNotImplemented) {
+				t.Errorf("Expected %s to be implemented, got %v", provider.ID, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrProviderNotImplemented) {
+			t.Errorf("Expected ErrProviderNotImplemented for %s, got %v", provider.ID, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"fmt"
+	"os"
+)
+
+// ContextFile is an external document, such as a design doc or RFC, attached
+// to a generation to explain the reasoning behind the code changes
+type ContextFile struct {
+	Path      string
+	Content   string
+	Truncated bool
+}
+
+// LoadContextFiles reads the given files and trims their contents so that,
+// together, they fit in the token budget. Files are filled in order, so files
+// listed later are the first to be truncated or dropped.
+func LoadContextFiles(paths []string, budget int) ([]ContextFile, error) {
+	var files []ContextFile
+	remaining := budget
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context file %s: %w", path, err)
+		}
+
+		if remaining <= 0 {
+			files = append(files, ContextFile{Path: path, Truncated: true})
+			continue
+		}
+
+		content := string(data)
+		truncated := false
+		if EstimateTokenCount(content) > remaining {
+			content = TruncateToTokens(content, remaining)
+			truncated = true
+		}
+		remaining -= EstimateTokenCount(content)
+
+		files = append(files, ContextFile{Path: path, Content: content, Truncated: truncated})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadContextFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	design := write("design.md", "# Design\nWe cache results per repository.\n")
+	rfc := write("rfc.md", "RFC: replace polling with webhooks.\n")
+	large := write("large.md", strings.Repeat("a long paragraph ", 100))
+
+	tests := []struct {
+		name    string
+		paths   []string
+		budget  int
+		wantErr bool
+		want    []ContextFile
+	}{
+		{
+			name:    "Missing file",
+			paths:   []string{design, filepath.Join(dir, "missing.md")},
+			budget:  1000,
+			wantErr: true,
+		},
+		{
+			name:   "Oversized file is truncated to the budget",
+			paths:  []string{large},
+			budget: 10,
+			want: []ContextFile{
+				{Path: large, Content: strings.Repeat("a long paragraph ", 100)[:40], Truncated: true},
+			},
+		},
+		{
+			name:   "Multiple files fit",
+			paths:  []string{design, rfc},
+			budget: 1000,
+			want: []ContextFile{
+				{Path: design, Content: "# Design\nWe cache results per repository.\n"},
+				{Path: rfc, Content: "RFC: replace polling with webhooks.\n"},
+			},
+		},
+		{
+			name:   "Later files are dropped once the budget is spent",
+			paths:  []string{large, design},
+			budget: 10,
+			want: []ContextFile{
+				{Path: large, Content: strings.Repeat("a long paragraph ", 100)[:40], Truncated: true},
+				{Path: design, Truncated: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := LoadContextFiles(tt.paths, tt.budget)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got files %+v", files)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadContextFiles returned error: %v", err)
+			}
+			if len(files) != len(tt.want) {
+				t.Fatalf("Expected %d files, got %d: %+v", len(tt.want), len(files), files)
+			}
+			for i, want := range tt.want {
+				if files[i] != want {
+					t.Errorf("File %d: expected %+v, got %+v", i, want, files[i])
+				}
+			}
+		})
+	}
+}
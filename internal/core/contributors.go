@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Contributor is a person who authored or co-authored some of a set of commits
+type Contributor struct {
+	Name  string
+	Email string
+	// Commits counts the commits they authored, and CoAuthored those they
+	// are credited on with a Co-authored-by trailer
+	Commits    int
+	CoAuthored int
+}
+
+// Contributors collects the distinct authors and co-authors of commits,
+// deduplicated by email, or by name when there is none. Names and emails are
+// as git log reports them, so a .mailmap has already merged a person's
+// identities. The most active contributors come first.
+func Contributors(commits []Commit) []Contributor {
+	var contributors []Contributor
+	indices := make(map[string]int)
+	add := func(name, email string) *Contributor {
+		key := strings.ToLower(strings.TrimSpace(email))
+		if key == "" {
+			key = strings.ToLower(strings.TrimSpace(name))
+		}
+		if key == "" {
+			return nil
+		}
+		if index, ok := indices[key]; ok {
+			return &contributors[index]
+		}
+		indices[key] = len(contributors)
+		contributors = append(contributors, Contributor{Name: name, Email: email})
+		return &contributors[len(contributors)-1]
+	}
+
+	for _, commit := range commits {
+		if contributor := add(commit.Author, commit.Email); contributor != nil {
+			contributor.Commits++
+		}
+		_, trailers := ParseTrailers(commit.Body)
+		for _, coAuthor := range trailers["Co-authored-by"] {
+			name, email := parseIdentity(coAuthor)
+			if strings.EqualFold(email, commit.Email) {
+				continue
+			}
+			if contributor := add(name, email); contributor != nil {
+				contributor.CoAuthored++
+			}
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Commits+contributors[i].CoAuthored > contributors[j].Commits+contributors[j].CoAuthored
+	})
+	return contributors
+}
+
+// parseIdentity splits a "Name <email>" identity, as used in trailers
+func parseIdentity(identity string) (string, string) {
+	name, rest, ok := strings.Cut(identity, "<")
+	if !ok {
+		return strings.TrimSpace(identity), ""
+	}
+	email, _, _ := strings.Cut(rest, ">")
+	return strings.TrimSpace(name), strings.TrimSpace(email)
+}
+
+// FormatContributors describes contributors for a prompt, e.g.
+// "Ana (3 commits), Ben (1 commit, 1 co-authored)"
+func FormatContributors(contributors []Contributor) string {
+	parts := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		name := contributor.Name
+		if name == "" {
+			name = contributor.Email
+		}
+
+		var counts []string
+		if contributor.Commits > 0 {
+			counts = append(counts, pluralize(contributor.Commits, "commit"))
+		}
+		if contributor.CoAuthored > 0 {
+			counts = append(counts, fmt.Sprintf("%d co-authored", contributor.CoAuthored))
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", name, strings.Join(counts, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize formats a count with its noun, adding an s unless count is 1
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestContributors(t *testing.T) {
+	commits := []Commit{
+		{Author: "Ben", Email: "ben@example.com"},
+		{Author: "Ana", Email: "ana@example.com", Body: "Pairing session.\n\nCo-authored-by: Ben B <BEN@example.com>\nCo-authored-by: Cy <cy@example.com>"},
+		{Author: "Ana", Email: "ana@example.com", Body: "Co-authored-by: Ana <ana@example.com>"},
+		{Author: "Ben Brown", Email: "ben@example.com"},
+	}
+
+	contributors := Contributors(commits)
+	if len(contributors) != 3 {
+		t.Fatalf("Expected 3 contributors, got %+v", contributors)
+	}
+	if got := FormatContributors(contributors); got != "Ben (2 commits, 1 co-authored), Ana (2 commits), Cy (1 co-authored)" {
+		t.Errorf("Unexpected contributors %q", got)
+	}
+
+	if len(Contributors(nil)) != 0 {
+		t.Error("Expected no contributors without commits")
+	}
+}
+
+func TestGetCommitLogsUsesMailmap(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	mailmap := "Jane Doe <jane@example.com> Test User <test@example.com>\n"
+	if err := os.WriteFile(filepath.Join(repoPath, ".mailmap"), []byte(mailmap), 0644); err != nil {
+		t.Fatalf("Failed to write .mailmap: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoPath, "add", ".mailmap").Run(); err != nil {
+		t.Fatalf("Failed to add .mailmap: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoPath, "commit", "-m", "Add mailmap").Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	page, err := GetCommitLogs(repoPath, 5, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs: %v", err)
+	}
+	for _, commit := range page.Commits {
+		if commit.Author != "Jane Doe" || commit.Email != "jane@example.com" {
+			t.Fatalf("Expected the mailmapped identity, got %s <%s>", commit.Author, commit.Email)
+		}
+	}
+
+	changeset, err := GetChangesForCommit(repoPath, page.Commits[0].Hash)
+	if err != nil {
+		t.Fatalf("Failed to get changeset: %v", err)
+	}
+	if changeset.Author != "Jane Doe" {
+		t.Errorf("Expected the mailmapped author, got %q", changeset.Author)
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ConventionalCommit holds the parts of a Conventional Commits subject such
+// as "feat(api)!: add pagination"
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// conventionalCommitPattern captures the type, optional scope, breaking
+// change marker and description of a conventional commit subject
+var conventionalCommitPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?: +(\S.*)$`)
+
+// ParseConventionalCommit splits a commit subject into its conventional commit
+// parts. The type is lowercased. It reports false when the subject doesn't
+// follow the convention.
+func ParseConventionalCommit(subject string) (ConventionalCommit, bool) {
+	match := conventionalCommitPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return ConventionalCommit{}, false
+	}
+	return ConventionalCommit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       strings.TrimSpace(match[2]),
+		Description: strings.TrimSpace(match[4]),
+		Breaking:    match[3] == "!",
+	}, true
+}
+
+// conventionalTypeWeights rank commit types by how interesting they are to
+// write about. Types not listed, and subjects that aren't conventional, get
+// defaultConventionalWeight.
+var conventionalTypeWeights = map[string]float64{
+	"feat":     1.0,
+	"fix":      0.8,
+	"perf":     0.7,
+	"refactor": 0.5,
+	"revert":   0.4,
+	"docs":     0.3,
+	"test":     0.1,
+	"style":    0.0,
+	"build":    0.0,
+	"ci":       0.0,
+	"chore":    0.0,
+}
+
+// defaultConventionalWeight is the weight of unknown and non-conventional commits
+const defaultConventionalWeight = 0.5
+
+// ConventionalTypeWeight rates a conventional commit type from 0 to 1, with
+// features and fixes highest and chores lowest
+func ConventionalTypeWeight(commitType string) float64 {
+	if weight, ok := conventionalTypeWeights[commitType]; ok {
+		return weight
+	}
+	return defaultConventionalWeight
+}
+
+// IsNoiseCommitType reports whether commits of this type rarely carry
+// anything worth writing about, like chores and CI changes
+func IsNoiseCommitType(commitType string) bool {
+	weight, ok := conventionalTypeWeights[commitType]
+	return ok && weight == 0
+}
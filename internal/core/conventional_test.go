@@ -0,0 +1,38 @@
+package core
+
+import "testing"
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    ConventionalCommit
+		ok      bool
+	}{
+		{"feat: add pagination", ConventionalCommit{Type: "feat", Description: "add pagination"}, true},
+		{"fix(listing): keep cursor in range", ConventionalCommit{Type: "fix", Scope: "listing", Description: "keep cursor in range"}, true},
+		{"Feat(api)!: drop v1 endpoints", ConventionalCommit{Type: "feat", Scope: "api", Description: "drop v1 endpoints", Breaking: true}, true},
+		{"chore:  bump deps", ConventionalCommit{Type: "chore", Description: "bump deps"}, true},
+		{"Add pagination to the listing", ConventionalCommit{}, false},
+		{"fix:", ConventionalCommit{}, false},
+		{"Merge branch 'main': sync", ConventionalCommit{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseConventionalCommit(tt.subject)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseConventionalCommit(%q) = %+v, %v, expected %+v, %v", tt.subject, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestConventionalTypeWeight(t *testing.T) {
+	if ConventionalTypeWeight("feat") <= ConventionalTypeWeight("chore") {
+		t.Error("Expected feat to outweigh chore")
+	}
+	if ConventionalTypeWeight("fix") <= ConventionalTypeWeight("") {
+		t.Error("Expected fix to outweigh non-conventional commits")
+	}
+	if !IsNoiseCommitType("chore") || IsNoiseCommitType("feat") || IsNoiseCommitType("") {
+		t.Error("Expected only chore to be noise")
+	}
+}
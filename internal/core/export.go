@@ -0,0 +1,42 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ContentExport is the JSON document written by ExportJSON, meant to be read
+// by publishing scripts
+type ContentExport struct {
+	Topic       string    `json:"topic"`
+	Format      string    `json:"format"`
+	Provider    string    `json:"provider"`
+	Model       string    `json:"model,omitempty"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Commits     []string  `json:"commits"`
+	Content     string    `json:"content"`
+}
+
+// ExportJSON writes export to path as indented JSON, creating its parent
+// directories if needed
+func ExportJSON(path string, export ContentExport) error {
+	if export.Commits == nil {
+		export.Commits = []string{}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write export to %s: %w", path, err)
+	}
+	return nil
+}
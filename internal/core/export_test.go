@@ -0,0 +1,72 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "post.json")
+	export := ContentExport{
+		Topic:       "Faster diffs",
+		Format:      "Blog Article",
+		Provider:    "Claude API",
+		Model:       "claude-sonnet-4",
+		GeneratedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Commits:     []string{"abc123", "def456"},
+		Content:     "# Faster diffs\n\nBody",
+	}
+
+	if err := ExportJSON(path, export); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"topic", "format", "provider", "model", "generated_at", "commits", "content"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("Expected key %q in export", key)
+		}
+	}
+
+	var got ContentExport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+	if got.Topic != export.Topic || got.Content != export.Content || !got.GeneratedAt.Equal(export.GeneratedAt) {
+		t.Errorf("Expected %+v, got %+v", export, got)
+	}
+	if len(got.Commits) != 2 || got.Commits[1] != "def456" {
+		t.Errorf("Expected commits %v, got %v", export.Commits, got.Commits)
+	}
+}
+
+func TestExportJSONWithoutCommits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post.json")
+	if err := ExportJSON(path, ContentExport{Topic: "Overview"}); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	if commits, ok := fields["commits"].([]any); !ok || len(commits) != 0 {
+		t.Errorf("Expected an empty commits array, got %v", fields["commits"])
+	}
+}
@@ -1,18 +1,35 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// ErrGitNotInstalled is returned by CheckGitInstalled when git can't be found
+var ErrGitNotInstalled = errors.New("git is not installed or not on PATH. Install it from https://git-scm.com/downloads and try again")
+
+// CheckGitInstalled reports whether the git executable every repository
+// operation shells out to can be found on PATH
+func CheckGitInstalled() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return ErrGitNotInstalled
+	}
+	return nil
+}
+
 // GetGitDirectory finds the git repository root directory by looking for a .git directory
 // in the current path or any parent directory. Returns the git root path and true if found,
-// or empty string and false if not found.
+// or empty string and false if not found. In worktrees and submodules .git is a file
+// pointing at the real git directory, which must exist for the root to count.
 func GetGitDirectory(path string) (string, bool, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -22,7 +39,12 @@ func GetGitDirectory(path string) (string, bool, error) {
 	current := absPath
 	for {
 		gitPath := filepath.Join(current, ".git")
-		if _, err := os.Stat(gitPath); err == nil {
+		if info, err := os.Stat(gitPath); err == nil {
+			if !info.IsDir() {
+				if _, err := ResolveGitDir(current); err != nil {
+					return "", false, err
+				}
+			}
 			return current, true, nil
 		}
 		
@@ -36,6 +58,37 @@ func GetGitDirectory(path string) (string, bool, error) {
 	return "", false, nil
 }
 
+// ResolveGitDir returns the git directory of the repository rooted at root:
+// root/.git itself, or where a .git file's "gitdir: <path>" line points, as
+// in worktrees and submodules. Relative gitdir paths are relative to root.
+func ResolveGitDir(root string) (string, error) {
+	gitPath := filepath.Join(root, ".git")
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return gitPath, nil
+	}
+
+	content, err := os.ReadFile(gitPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", gitPath, err)
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("invalid .git file %s: no gitdir line", gitPath)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(root, gitDir)
+	}
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("git directory %s in %s not found", gitDir, gitPath)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
 type Commit struct {
 	Hash      string
 	Author    string
@@ -43,6 +96,20 @@ type Commit struct {
 	Date      time.Time
 	Subject   string
 	Body      string
+	// Insertions and Deletions count changed lines, excluding binary files
+	Insertions int
+	Deletions  int
+	// FilesChanged counts the changed files, and TopDir is the top-level
+	// directory most of them are in, empty when most are at the root
+	FilesChanged int
+	TopDir       string
+	// Paths are the changed files, renames under their new path
+	Paths []string
+	// Type, Scope and Description are parsed from conventional commit
+	// subjects like "feat(api): add pagination", and empty otherwise
+	Type        string
+	Scope       string
+	Description string
 }
 
 type CommitPage struct {
@@ -53,7 +120,110 @@ type CommitPage struct {
 	Total     int
 }
 
+// CommitLogOptions narrows down the commits returned by GetCommitLogsWithOptions
+type CommitLogOptions struct {
+	// Path limits the log to commits touching the given path, relative to the repository root
+	Path string
+	// Range is a revision or revision range such as HEAD~10..HEAD, defaulting to HEAD
+	Range string
+	// Ref is the branch or ref whose history is listed, defaulting to HEAD.
+	// It is ignored when Range is set.
+	Ref string
+	// Follow continues the history of Path past renames, like git log
+	// --follow. Git only supports it for a single pathspec, which Path always
+	// is; for a directory it makes no difference.
+	Follow bool
+}
+
+// follows reports whether the log should follow renames of Path
+func (o CommitLogOptions) follows() bool {
+	return o.Follow && o.Path != ""
+}
+
+// revListArgs returns the revision and pathspec arguments for git log/rev-list
+func (o CommitLogOptions) revListArgs() []string {
+	revision := "HEAD"
+	if o.Range != "" {
+		revision = o.Range
+	} else if o.Ref != "" {
+		revision = o.Ref
+	}
+	args := []string{revision}
+	if o.Path != "" {
+		args = append(args, "--", o.Path)
+	}
+	return args
+}
+
+// HasCommits reports whether HEAD points at a commit, which it doesn't in a
+// repository nothing has been committed to yet
+func HasCommits(repoPath string) bool {
+	return exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", "HEAD^{commit}").Run() == nil
+}
+
+// ValidateRevisionRange checks that a revision or revision range such as
+// HEAD~10..HEAD resolves in the repository
+func ValidateRevisionRange(repoPath, revisionRange string) error {
+	if revisionRange == "" || strings.HasPrefix(revisionRange, "-") {
+		return fmt.Errorf("invalid revision range %q", revisionRange)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", revisionRange, "--")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("invalid revision range %q: %s", revisionRange, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ResolveCommit resolves a commit-ish such as a hash, tag or HEAD~2 to the
+// full hash of the commit it names
+func ResolveCommit(repoPath, rev string) (string, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("invalid commit %q", rev)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%q is not a commit in this repository", rev)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ListBranches returns the short names of the local branches in the repository
+func ListBranches(repoPath string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "branch", "--format=%(refname:short)")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if branch := strings.TrimSpace(line); branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+// CurrentBranch returns the short name of the checked out branch, or an
+// empty string when HEAD is detached
+func CurrentBranch(repoPath string) string {
+	cmd := exec.Command("git", "-C", repoPath, "symbolic-ref", "--short", "-q", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 func GetCommitLogs(repoPath string, perPage, pageNum int) (*CommitPage, error) {
+	return GetCommitLogsWithOptions(repoPath, CommitLogOptions{}, perPage, pageNum)
+}
+
+// GetCommitLogsWithOptions returns a page of commits filtered by the given options
+func GetCommitLogsWithOptions(repoPath string, opts CommitLogOptions, perPage, pageNum int) (*CommitPage, error) {
 	if !filepath.IsAbs(repoPath) {
 		absPath, err := filepath.Abs(repoPath)
 		if err != nil {
@@ -75,12 +245,21 @@ func GetCommitLogs(repoPath string, perPage, pageNum int) (*CommitPage, error) {
 	skip := (pageNum - 1) * perPage
 	limit := perPage + 1
 
-	format := "--pretty=format:%H|%an|%ae|%at|%s|%b|||END|||"
+	format := "--pretty=format:" + commitLogFormat
 	
-	cmd := exec.Command("git", "-C", repoPath, "log", fmt.Sprintf("--skip=%d", skip), fmt.Sprintf("--max-count=%d", limit), format)
+	args := []string{"-C", repoPath, "log", fmt.Sprintf("--skip=%d", skip), fmt.Sprintf("--max-count=%d", limit), "--numstat", "--root", format}
+	if opts.follows() {
+		args = append(args, "--follow")
+	}
+	args = append(args, opts.revListArgs()...)
+	cmd := exec.Command("git", args...)
 	
 	output, err := cmd.Output()
 	if err != nil {
+		// A freshly initialized repository has no HEAD to log from yet
+		if opts.Range == "" && opts.Ref == "" && !HasCommits(repoPath) {
+			return &CommitPage{Commits: []Commit{}, PageNum: pageNum, PerPage: perPage}, nil
+		}
 		return nil, fmt.Errorf("failed to execute git log: %w", err)
 	}
 
@@ -94,7 +273,7 @@ func GetCommitLogs(repoPath string, perPage, pageNum int) (*CommitPage, error) {
 		commits = commits[:perPage]
 	}
 
-	total, err := getTotalCommitCount(repoPath)
+	total, err := getTotalCommitCount(repoPath, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total commit count: %w", err)
 	}
@@ -108,21 +287,38 @@ func GetCommitLogs(repoPath string, perPage, pageNum int) (*CommitPage, error) {
 	}, nil
 }
 
+// Markers around each commit's metadata in the git log output, and between
+// its fields. Control characters are used because they can't appear in author
+// names or subjects, unlike | which is common in commit messages. The
+// --numstat lines for a commit follow its end marker.
+const (
+	commitStartMarker = "\x1e"
+	commitEndMarker   = "\x1d"
+	fieldSeparator    = "\x00"
+)
+
+// commitLogFormat prints hash, author, email, timestamp, subject and body
+// between the commit markers, using git's %x escapes for the markers. The
+// author's name and email go through the repository's .mailmap.
+const commitLogFormat = "%x1e%H%x00%aN%x00%aE%x00%at%x00%s%x00%b%x1d"
+
 func parseCommits(output string) ([]Commit, error) {
 	if strings.TrimSpace(output) == "" {
 		return []Commit{}, nil
 	}
 
-	parts := strings.Split(output, "|||END|||\n")
+	parts := strings.Split(output, commitStartMarker)
 	commits := make([]Commit, 0, len(parts))
 
 	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
+		if strings.TrimSpace(part) == "" {
 			continue
 		}
 
-		fields := strings.SplitN(part, "|", 6)
+		metadata, numstat, _ := strings.Cut(part, commitEndMarker)
+		metadata = strings.TrimSpace(metadata)
+
+		fields := strings.SplitN(metadata, fieldSeparator, 6)
 		if len(fields) < 5 {
 			continue
 		}
@@ -145,6 +341,21 @@ func parseCommits(output string) ([]Commit, error) {
 			Subject: fields[4],
 			Body:    body,
 		}
+		stats := parseNumstat(numstat)
+		paths := make([]string, 0, len(stats))
+		for _, stat := range stats {
+			commit.Insertions += stat.Insertions
+			commit.Deletions += stat.Deletions
+			paths = append(paths, numstatPath(stat.Path))
+		}
+		commit.FilesChanged = len(stats)
+		commit.TopDir = topDirectory(paths)
+		commit.Paths = paths
+		if conventional, ok := ParseConventionalCommit(commit.Subject); ok {
+			commit.Type = conventional.Type
+			commit.Scope = conventional.Scope
+			commit.Description = conventional.Description
+		}
 
 		commits = append(commits, commit)
 	}
@@ -152,14 +363,61 @@ func parseCommits(output string) ([]Commit, error) {
 	return commits, nil
 }
 
+// renamedPathPattern matches the rename notation git's --numstat uses, like
+// "src/{old => new}/file.go"
+var renamedPathPattern = regexp.MustCompile(`\{[^{}]* => ([^{}]*)\}`)
+
+// numstatPath returns the path of a --numstat entry, taking the new path of
+// a rename
+func numstatPath(name string) string {
+	name = renamedPathPattern.ReplaceAllString(name, "$1")
+	if _, newName, ok := strings.Cut(name, " => "); ok {
+		name = newName
+	}
+	return strings.TrimPrefix(path.Clean(name), "/")
+}
+
+// topDirectory returns the top-level directory holding most of paths, or an
+// empty string when most are at the repository root. Renames count under
+// their new path.
+func topDirectory(paths []string) string {
+	counts := make(map[string]int)
+	best, bestCount := "", 0
+	for _, name := range paths {
+		name = numstatPath(name)
+
+		dir := ""
+		if first, _, ok := strings.Cut(name, "/"); ok {
+			dir = first
+		}
+		counts[dir]++
+		// Ties go to the directory seen first
+		if counts[dir] > bestCount {
+			best, bestCount = dir, counts[dir]
+		}
+	}
+	return best
+}
+
 func reverseCommits(commits []Commit) {
 	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
 		commits[i], commits[j] = commits[j], commits[i]
 	}
 }
 
-func getTotalCommitCount(repoPath string) (int, error) {
-	cmd := exec.Command("git", "-C", repoPath, "rev-list", "--count", "HEAD")
+func getTotalCommitCount(repoPath string, opts CommitLogOptions) (int, error) {
+	// rev-list can't follow renames, so the followed log's commits are counted
+	if opts.follows() {
+		args := append([]string{"-C", repoPath, "log", "--follow", "--pretty=format:%H"}, opts.revListArgs()...)
+		output, err := exec.Command("git", args...).Output()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get commit count: %w", err)
+		}
+		return len(strings.Fields(string(output))), nil
+	}
+
+	args := append([]string{"-C", repoPath, "rev-list", "--count"}, opts.revListArgs()...)
+	cmd := exec.Command("git", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get commit count: %w", err)
@@ -201,7 +459,20 @@ func GetCommitChangelist(repoPath, commitHash string) ([]byte, error) {
 	return output, nil
 }
 
-// GetCommitDiff returns the full diff for a given commit
+// showDiffArgs make git show diff merge commits against their first parent,
+// which is what the merge brought in, and root commits against the empty
+// tree so their whole content shows as added even when log.showRoot is off
+var showDiffArgs = []string{"-m", "--first-parent", "--root"}
+
+// showCommand builds a git show command for commitHash with showDiffArgs
+func showCommand(repoPath, commitHash string, args ...string) *exec.Cmd {
+	showArgs := append([]string{"-C", repoPath, "show"}, showDiffArgs...)
+	showArgs = append(showArgs, args...)
+	return exec.Command("git", append(showArgs, commitHash)...)
+}
+
+// GetCommitDiff returns the full diff for a given commit, or the uncommitted
+// changes for WorkingTreeHash
 func GetCommitDiff(repoPath, commitHash string) ([]byte, error) {
 	if !filepath.IsAbs(repoPath) {
 		absPath, err := filepath.Abs(repoPath)
@@ -221,13 +492,84 @@ func GetCommitDiff(repoPath, commitHash string) ([]byte, error) {
 	
 	repoPath = gitRoot
 
-	cmd := exec.Command("git", "-C", repoPath, "show", "--format=", commitHash)
+	cmd := showCommand(repoPath, commitHash, "--format=")
+	if commitHash == WorkingTreeHash {
+		cmd = exec.Command("git", "-C", repoPath, "diff", "HEAD")
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get diff for commit %s: %w", commitHash, err)
 	}
 
-	return output, nil
+	ignore, err := LoadIgnoreFile(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(ignore.FilterDiff(string(output))), nil
+}
+
+// FileStat holds the number of lines added and removed in a file by a commit.
+// Binary files report zero for both.
+type FileStat struct {
+	Path       string
+	Insertions int
+	Deletions  int
+	Binary     bool
+}
+
+// GetCommitFileStats returns the per-file line stats for a given commit, or
+// for the uncommitted changes with WorkingTreeHash
+func GetCommitFileStats(repoPath, commitHash string) ([]FileStat, error) {
+	if !filepath.IsAbs(repoPath) {
+		absPath, err := filepath.Abs(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path: %w", err)
+		}
+		repoPath = absPath
+	}
+
+	gitRoot, isRepo, err := GetGitDirectory(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if directory is a git repository: %w", err)
+	}
+	if !isRepo {
+		return nil, fmt.Errorf("directory %s is not a git repository", repoPath)
+	}
+
+	repoPath = gitRoot
+
+	cmd := showCommand(repoPath, commitHash, "--numstat", "--format=")
+	if commitHash == WorkingTreeHash {
+		cmd = exec.Command("git", "-C", repoPath, "diff", "HEAD", "--numstat")
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file stats for commit %s: %w", commitHash, err)
+	}
+
+	return parseNumstat(string(output)), nil
+}
+
+// parseNumstat parses the output of git's --numstat option
+func parseNumstat(output string) []FileStat {
+	var stats []FileStat
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		stat := FileStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Insertions, _ = strconv.Atoi(parts[0])
+			stat.Deletions, _ = strconv.Atoi(parts[1])
+		}
+		stats = append(stats, stat)
+	}
+	return stats
 }
 
 // EstimateTokenCount provides a rough estimate of token count for text
@@ -236,6 +578,78 @@ func EstimateTokenCount(text string) int {
 	return len(text) / 4
 }
 
+// TruncateToTokens cuts text down to roughly the given number of tokens,
+// using the same approximation as EstimateTokenCount, without splitting a
+// multi-byte character
+func TruncateToTokens(text string, tokens int) string {
+	maxBytes := tokens * 4
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	if len(text) <= maxBytes {
+		return text
+	}
+	for maxBytes > 0 && !utf8.RuneStart(text[maxBytes]) {
+		maxBytes--
+	}
+	return text[:maxBytes]
+}
+
+// DefaultMaxDiffTokens is the per-commit diff size kept when a diff has to be
+// shortened, unless COMMITLORE_MAX_DIFF_TOKENS is set
+const DefaultMaxDiffTokens = 1500
+
+// MaxDiffTokens returns the per-commit diff budget used when truncating diffs,
+// from COMMITLORE_MAX_DIFF_TOKENS or DefaultMaxDiffTokens
+func MaxDiffTokens() int {
+	if tokens, err := strconv.Atoi(os.Getenv("COMMITLORE_MAX_DIFF_TOKENS")); err == nil && tokens > 0 {
+		return tokens
+	}
+	return DefaultMaxDiffTokens
+}
+
+// TruncateDiff shortens a diff to roughly the given number of tokens by
+// dropping whole files and hunks from the end, so what is left still reads
+// as a valid diff. If even the first hunk is too large it is cut at a line
+// boundary. It reports whether anything was dropped.
+func TruncateDiff(diff string, tokens int) (string, bool) {
+	if EstimateTokenCount(diff) <= tokens {
+		return diff, false
+	}
+	maxBytes := tokens * 4
+
+	// Cut points are the starts of files and of hunks other than a file's
+	// first, so a file header is never left without any hunk
+	cut := 0
+	firstHunk := false
+	offset := 0
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if offset > maxBytes {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "diff --git") || strings.HasPrefix(line, "diff --cc"):
+			cut = offset
+			firstHunk = true
+		case strings.HasPrefix(line, "@@"):
+			if !firstHunk {
+				cut = offset
+			}
+			firstHunk = false
+		}
+		offset += len(line)
+	}
+
+	if cut == 0 {
+		truncated := TruncateToTokens(diff, tokens)
+		if i := strings.LastIndex(truncated, "\n"); i > 0 {
+			truncated = truncated[:i+1]
+		}
+		return truncated, true
+	}
+	return diff[:cut], true
+}
+
 // FormatTokenCount formats token count in human-readable format (e.g., 2.3k, 1.5M)
 func FormatTokenCount(count int) string {
 	if count < 1000 {
@@ -256,10 +670,21 @@ type Changeset struct {
 	Body       string
 	Diff       string
 	Files      []string
+	// IsMerge is set for commits with more than one parent, whose Diff and
+	// Files are taken against the first parent
+	IsMerge bool
+	// Trailers holds the commit's trailers, such as Co-authored-by and
+	// Signed-off-by, keyed by name. Body still includes them.
+	Trailers map[string][]string
 }
 
-// GetChangesForCommit retrieves detailed changeset for a specific commit
+// GetChangesForCommit retrieves detailed changeset for a specific commit.
+// WorkingTreeHash returns the uncommitted changes instead.
 func GetChangesForCommit(repoPath, commitHash string) (Changeset, error) {
+	if commitHash == WorkingTreeHash {
+		return GetWorkingTreeChangeset(repoPath)
+	}
+
 	if !filepath.IsAbs(repoPath) {
 		absPath, err := filepath.Abs(repoPath)
 		if err != nil {
@@ -279,15 +704,15 @@ func GetChangesForCommit(repoPath, commitHash string) (Changeset, error) {
 	repoPath = gitRoot
 
 	// Get commit metadata
-	metaCmd := exec.Command("git", "-C", repoPath, "show", "--format=%an|%at|%s|%b", "--no-patch", commitHash)
+	metaCmd := exec.Command("git", "-C", repoPath, "show", "--format=%aN%x00%at%x00%P%x00%s%x00%b", "--no-patch", commitHash)
 	metaOutput, err := metaCmd.Output()
 	if err != nil {
 		return Changeset{}, fmt.Errorf("failed to get commit metadata for %s: %w", commitHash, err)
 	}
 
 	// Parse metadata
-	metaParts := strings.SplitN(strings.TrimSpace(string(metaOutput)), "|", 4)
-	if len(metaParts) < 3 {
+	metaParts := strings.SplitN(strings.TrimSpace(string(metaOutput)), fieldSeparator, 5)
+	if len(metaParts) < 4 {
 		return Changeset{}, fmt.Errorf("invalid commit metadata format")
 	}
 
@@ -303,7 +728,7 @@ func GetChangesForCommit(repoPath, commitHash string) (Changeset, error) {
 	}
 
 	// Get changed files
-	filesCmd := exec.Command("git", "-C", repoPath, "show", "--name-only", "--format=", commitHash)
+	filesCmd := showCommand(repoPath, commitHash, "--name-only", "--format=")
 	filesOutput, err := filesCmd.Output()
 	if err != nil {
 		return Changeset{}, fmt.Errorf("failed to get changed files: %w", err)
@@ -317,20 +742,296 @@ func GetChangesForCommit(repoPath, commitHash string) (Changeset, error) {
 		}
 	}
 
+	ignore, err := LoadIgnoreFile(repoPath)
+	if err != nil {
+		return Changeset{}, err
+	}
+	files = ignore.FilterFiles(files)
+
 	body := ""
-	if len(metaParts) > 3 {
-		body = strings.TrimSpace(metaParts[3])
+	if len(metaParts) > 4 {
+		body = strings.TrimSpace(metaParts[4])
 	}
+	_, trailers := ParseTrailers(body)
 
 	changeset := Changeset{
 		CommitHash: commitHash,
 		Author:     metaParts[0],
 		Date:       time.Unix(timestamp, 0),
-		Subject:    metaParts[2],
+		Subject:    metaParts[3],
+		Body:       body,
+		Diff:       string(diff),
+		Files:      files,
+		IsMerge:    len(strings.Fields(metaParts[2])) > 1,
+		Trailers:   trailers,
+	}
+
+	return changeset, nil
+}
+
+// GetChangesBetweenCommits retrieves a single changeset describing the
+// difference between two commits, as produced by `git diff fromHash toHash`.
+// The metadata is taken from toHash and the body lists the commits in between.
+func GetChangesBetweenCommits(repoPath, fromHash, toHash string) (Changeset, error) {
+	if !filepath.IsAbs(repoPath) {
+		absPath, err := filepath.Abs(repoPath)
+		if err != nil {
+			return Changeset{}, fmt.Errorf("failed to get absolute path: %w", err)
+		}
+		repoPath = absPath
+	}
+
+	gitRoot, isRepo, err := GetGitDirectory(repoPath)
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to check if directory is a git repository: %w", err)
+	}
+	if !isRepo {
+		return Changeset{}, fmt.Errorf("directory %s is not a git repository", repoPath)
+	}
+	
+	repoPath = gitRoot
+
+	// Get metadata of the target commit
+	metaCmd := exec.Command("git", "-C", repoPath, "show", "--format=%aN%x00%at", "--no-patch", toHash)
+	metaOutput, err := metaCmd.Output()
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to get commit metadata for %s: %w", toHash, err)
+	}
+
+	metaParts := strings.SplitN(strings.TrimSpace(string(metaOutput)), fieldSeparator, 2)
+	if len(metaParts) < 2 {
+		return Changeset{}, fmt.Errorf("invalid commit metadata format")
+	}
+
+	timestamp, err := strconv.ParseInt(metaParts[1], 10, 64)
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+
+	// Get diff
+	diffCmd := exec.Command("git", "-C", repoPath, "diff", fromHash, toHash)
+	diff, err := diffCmd.Output()
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to get diff between %s and %s: %w", fromHash, toHash, err)
+	}
+
+	// Get changed files
+	filesCmd := exec.Command("git", "-C", repoPath, "diff", "--name-only", fromHash, toHash)
+	filesOutput, err := filesCmd.Output()
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(string(filesOutput), "\n") {
+		file = strings.TrimSpace(file)
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	ignore, err := LoadIgnoreFile(repoPath)
+	if err != nil {
+		return Changeset{}, err
+	}
+	files = ignore.FilterFiles(files)
+	diff = []byte(ignore.FilterDiff(string(diff)))
+
+	// Get the commits in between for context
+	logCmd := exec.Command("git", "-C", repoPath, "log", "--format=%h %s", fromHash+".."+toHash)
+	logOutput, err := logCmd.Output()
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to list commits between %s and %s: %w", fromHash, toHash, err)
+	}
+
+	body := ""
+	if log := strings.TrimSpace(string(logOutput)); log != "" {
+		body = "Commits in range:\n" + log
+	}
+
+	hashLength := HashLength(repoPath)
+	changeset := Changeset{
+		CommitHash: fromHash + ".." + toHash,
+		Author:     metaParts[0],
+		Date:       time.Unix(timestamp, 0),
+		Subject:    fmt.Sprintf("Changes from %s to %s", AbbreviateHash(fromHash, hashLength), AbbreviateHash(toHash, hashLength)),
 		Body:       body,
 		Diff:       string(diff),
 		Files:      files,
 	}
 
 	return changeset, nil
-}
\ No newline at end of file
+}
+
+// DefaultHashLength is the abbreviated hash length used when neither
+// COMMITLORE_HASH_LENGTH nor git's core.abbrev set one
+const DefaultHashLength = 7
+
+// minHashLength is the shortest abbreviation git itself accepts
+const minHashLength = 4
+
+// HashLength returns the number of characters to show for abbreviated commit
+// hashes. COMMITLORE_HASH_LENGTH takes precedence, followed by the repository's
+// core.abbrev setting, falling back to DefaultHashLength.
+func HashLength(repoPath string) int {
+	if length, ok := parseHashLength(os.Getenv("COMMITLORE_HASH_LENGTH")); ok {
+		return length
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "config", "--get", "core.abbrev")
+	output, err := cmd.Output()
+	if err == nil {
+		if length, ok := parseHashLength(strings.TrimSpace(string(output))); ok {
+			return length
+		}
+	}
+
+	return DefaultHashLength
+}
+
+// parseHashLength parses a hash length setting, accepting "no" as the full
+// hash like core.abbrev does. Values such as "auto" are not supported.
+func parseHashLength(value string) (int, bool) {
+	if value == "no" {
+		return 40, true
+	}
+	length, err := strconv.Atoi(value)
+	if err != nil || length < minHashLength {
+		return 0, false
+	}
+	if length > 40 {
+		length = 40
+	}
+	return length, true
+}
+
+// AbbreviateHash shortens a commit hash to the given length
+func AbbreviateHash(hash string, length int) string {
+	if length > 0 && len(hash) > length {
+		return hash[:length]
+	}
+	return hash
+}
+
+// RelativeRepoPath converts a user supplied path into a path relative to the
+// repository root. Absolute paths and paths that exist relative to the current
+// working directory are resolved first; anything else is taken as repo-relative.
+func RelativeRepoPath(repoRoot, path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+
+	absPath := path
+	if !filepath.IsAbs(path) {
+		cwdPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to get absolute path: %w", err)
+		}
+		if _, err := os.Stat(cwdPath); err != nil {
+			// Not found from the working directory, treat it as repo-relative
+			return filepath.ToSlash(filepath.Clean(path)), nil
+		}
+		absPath = cwdPath
+	}
+
+	relPath, err := filepath.Rel(repoRoot, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", path, err)
+	}
+	if relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the repository", path)
+	}
+
+	return filepath.ToSlash(relPath), nil
+}
+
+// WorkingTreeHash is the synthetic hash used for the uncommitted changes in
+// place of a commit hash
+const WorkingTreeHash = "WORKING"
+
+// WorkingTreeSubject is the subject shown for the uncommitted changes
+const WorkingTreeSubject = "Uncommitted changes"
+
+// GetWorkingTreeChangeset builds a changeset from the staged and unstaged
+// changes to tracked files, compared with HEAD. Untracked files are not
+// included. The diff is empty when there is nothing to commit.
+func GetWorkingTreeChangeset(repoPath string) (Changeset, error) {
+	gitRoot, isRepo, err := GetGitDirectory(repoPath)
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to check if directory is a git repository: %w", err)
+	}
+	if !isRepo {
+		return Changeset{}, fmt.Errorf("directory %s is not a git repository", repoPath)
+	}
+
+	repoPath = gitRoot
+
+	diff, err := GetCommitDiff(repoPath, WorkingTreeHash)
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to get uncommitted changes: %w", err)
+	}
+
+	filesCmd := exec.Command("git", "-C", repoPath, "diff", "HEAD", "--name-only")
+	filesOutput, err := filesCmd.Output()
+	if err != nil {
+		return Changeset{}, fmt.Errorf("failed to get uncommitted files: %w", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(strings.TrimSpace(string(filesOutput)), "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	ignore, err := LoadIgnoreFile(repoPath)
+	if err != nil {
+		return Changeset{}, err
+	}
+
+	return Changeset{
+		CommitHash: WorkingTreeHash,
+		Author:     gitUserName(repoPath),
+		Date:       time.Now(),
+		Subject:    WorkingTreeSubject,
+		Diff:       string(diff),
+		Files:      ignore.FilterFiles(files),
+	}, nil
+}
+
+// GetWorkingTreeCommit describes the uncommitted changes as a commit with the
+// synthetic WorkingTreeHash, so they can be listed and selected like one. It
+// returns nil when the working tree is clean.
+func GetWorkingTreeCommit(repoPath string) (*Commit, error) {
+	stats, err := GetCommitFileStats(repoPath, WorkingTreeHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+
+	gitRoot, _, _ := GetGitDirectory(repoPath)
+	commit := &Commit{
+		Hash:    WorkingTreeHash,
+		Author:  gitUserName(gitRoot),
+		Date:    time.Now(),
+		Subject: WorkingTreeSubject,
+	}
+	for _, stat := range stats {
+		commit.Insertions += stat.Insertions
+		commit.Deletions += stat.Deletions
+		commit.Paths = append(commit.Paths, numstatPath(stat.Path))
+	}
+	return commit, nil
+}
+
+// gitUserName returns the configured user.name, or an empty string
+func gitUserName(repoPath string) string {
+	output, err := exec.Command("git", "-C", repoPath, "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
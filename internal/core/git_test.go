@@ -1,10 +1,13 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -216,11 +219,45 @@ func TestGetGitDirectory(t *testing.T) {
 		_ = isGit
 		_ = gitRoot
 	})
+
+	t.Run("Git file pointing at a git directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		realGitDir := filepath.Join(tmpDir, "real-git-dir")
+		if err := os.Mkdir(realGitDir, 0755); err != nil {
+			t.Fatalf("Failed to create git directory: %v", err)
+		}
+		root := filepath.Join(tmpDir, "checkout")
+		if err := os.Mkdir(root, 0755); err != nil {
+			t.Fatalf("Failed to create checkout: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, ".git"), []byte("gitdir: ../real-git-dir\n"), 0644); err != nil {
+			t.Fatalf("Failed to write .git file: %v", err)
+		}
+
+		gitRoot, isGit, err := GetGitDirectory(root)
+		if err != nil || !isGit || gitRoot != root {
+			t.Errorf("Expected git root '%s', got '%s', %v, %v", root, gitRoot, isGit, err)
+		}
+		gitDir, err := ResolveGitDir(root)
+		if err != nil || gitDir != realGitDir {
+			t.Errorf("Expected git dir '%s', got '%s', %v", realGitDir, gitDir, err)
+		}
+	})
+
+	t.Run("Git file pointing nowhere", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, ".git"), []byte("gitdir: /does/not/exist\n"), 0644); err != nil {
+			t.Fatalf("Failed to write .git file: %v", err)
+		}
+		if _, isGit, err := GetGitDirectory(tmpDir); err == nil || isGit {
+			t.Errorf("Expected an error for a dangling .git file, got %v, %v", isGit, err)
+		}
+	})
 }
 
 func createTestRepo(t *testing.T) string {
 	t.Helper()
-	
+
 	tmpDir := t.TempDir()
 
 	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
@@ -238,7 +275,7 @@ func createTestRepo(t *testing.T) string {
 	for i := 1; i <= 20; i++ {
 		filename := fmt.Sprintf("file%d.txt", i)
 		content := fmt.Sprintf("This is file %d\nContent for commit %d", i, i)
-		
+
 		filePath := filepath.Join(tmpDir, filename)
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			t.Fatalf("Failed to create file %s: %v", filename, err)
@@ -259,6 +296,17 @@ func createTestRepo(t *testing.T) string {
 	return tmpDir
 }
 
+func TestCheckGitInstalled(t *testing.T) {
+	if err := CheckGitInstalled(); err != nil {
+		t.Fatalf("Expected git to be found, got %v", err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := CheckGitInstalled(); !errors.Is(err, ErrGitNotInstalled) {
+		t.Errorf("Expected ErrGitNotInstalled, got %v", err)
+	}
+}
+
 func TestGetCommitLogs(t *testing.T) {
 	repoPath := createTestRepo(t)
 
@@ -419,7 +467,7 @@ func TestGetCommitLogs(t *testing.T) {
 			}
 
 			if commit.Date.After(prevTime) {
-				t.Errorf("Commits not in descending order: commit %d (%s) is after commit %d (%s)", 
+				t.Errorf("Commits not in descending order: commit %d (%s) is after commit %d (%s)",
 					i, commit.Date.Format(time.RFC3339), i-1, prevTime.Format(time.RFC3339))
 			}
 			prevTime = commit.Date
@@ -487,4 +535,727 @@ func TestGetCommitLogs(t *testing.T) {
 			t.Error("Expected non-empty subject")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestGetChangesForCommit(t *testing.T) {
+	t.Run("Single commit", func(t *testing.T) {
+		repoPath := createTestRepo(t)
+
+		page, err := GetCommitLogs(repoPath, 2, 1)
+		if err != nil {
+			t.Fatalf("Failed to get commit logs: %v", err)
+		}
+		commit := page.Commits[1]
+
+		changeset, err := GetChangesForCommit(repoPath, commit.Hash)
+		if err != nil {
+			t.Fatalf("Failed to get changeset: %v", err)
+		}
+
+		if changeset.CommitHash != commit.Hash {
+			t.Errorf("Expected commit hash '%s', got '%s'", commit.Hash, changeset.CommitHash)
+		}
+		if changeset.Author != "Test User" {
+			t.Errorf("Expected author 'Test User', got '%s'", changeset.Author)
+		}
+		if changeset.Subject != "Commit 19: Add file19.txt" {
+			t.Errorf("Expected subject 'Commit 19: Add file19.txt', got '%s'", changeset.Subject)
+		}
+		if changeset.Body != "" {
+			t.Errorf("Expected empty body, got '%s'", changeset.Body)
+		}
+		if !changeset.Date.Equal(commit.Date) {
+			t.Errorf("Expected date %v, got %v", commit.Date, changeset.Date)
+		}
+		if len(changeset.Files) != 1 || changeset.Files[0] != "file19.txt" {
+			t.Errorf("Expected files [file19.txt], got %v", changeset.Files)
+		}
+		if !strings.Contains(changeset.Diff, "+This is file 19") {
+			t.Errorf("Expected diff to add file19.txt, got '%s'", changeset.Diff)
+		}
+	})
+
+	t.Run("Trailers", func(t *testing.T) {
+		repoPath := createTestRepo(t)
+
+		if err := os.WriteFile(filepath.Join(repoPath, "retry.go"), []byte("package retry\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := exec.Command("git", "-C", repoPath, "add", "retry.go").Run(); err != nil {
+			t.Fatalf("Failed to add file: %v", err)
+		}
+		message := "feat: retry requests\n\nBack off on 429s.\n\nCo-authored-by: Ana <ana@example.com>\nFixes: #12"
+		if err := exec.Command("git", "-C", repoPath, "commit", "-m", message).Run(); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+
+		changeset, err := GetChangesForCommit(repoPath, "HEAD")
+		if err != nil {
+			t.Fatalf("Failed to get changeset: %v", err)
+		}
+
+		if changeset.Body != "Back off on 429s.\n\nCo-authored-by: Ana <ana@example.com>\nFixes: #12" {
+			t.Errorf("Expected the body with its trailers, got %q", changeset.Body)
+		}
+		if coAuthors := changeset.CoAuthors(); len(coAuthors) != 1 || coAuthors[0] != "Ana <ana@example.com>" {
+			t.Errorf("Expected one co-author, got %v", coAuthors)
+		}
+		if refs := changeset.IssueReferences(); len(refs) != 1 || refs[0] != "#12" {
+			t.Errorf("Expected a reference to #12, got %v", refs)
+		}
+	})
+
+	t.Run("Multi-line body with pipes", func(t *testing.T) {
+		repoPath := createTestRepo(t)
+
+		if err := os.WriteFile(filepath.Join(repoPath, "table.md"), []byte("| a | b |\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := exec.Command("git", "-C", repoPath, "add", "table.md").Run(); err != nil {
+			t.Fatalf("Failed to add file: %v", err)
+		}
+		body := "First line | with a pipe\n\n| col | col |\n|-----|-----|"
+		if err := exec.Command("git", "-C", repoPath, "commit", "-m", "docs: add table", "-m", body).Run(); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+
+		changeset, err := GetChangesForCommit(repoPath, "HEAD")
+		if err != nil {
+			t.Fatalf("Failed to get changeset: %v", err)
+		}
+
+		if changeset.Subject != "docs: add table" {
+			t.Errorf("Expected subject 'docs: add table', got '%s'", changeset.Subject)
+		}
+		if changeset.Body != body {
+			t.Errorf("Expected body %q, got %q", body, changeset.Body)
+		}
+		if len(changeset.Files) != 1 || changeset.Files[0] != "table.md" {
+			t.Errorf("Expected files [table.md], got %v", changeset.Files)
+		}
+		if changeset.Diff == "" {
+			t.Error("Expected non-empty diff")
+		}
+	})
+
+	t.Run("Root commit", func(t *testing.T) {
+		repoPath := createTestRepo(t)
+
+		// Without --root this setting hides the initial commit's diff
+		if err := exec.Command("git", "-C", repoPath, "config", "log.showRoot", "false").Run(); err != nil {
+			t.Fatalf("Failed to set log.showRoot: %v", err)
+		}
+
+		page, err := GetCommitLogs(repoPath, 20, 1)
+		if err != nil {
+			t.Fatalf("Failed to get commit logs: %v", err)
+		}
+		first := page.Commits[len(page.Commits)-1]
+		if first.Subject != "Commit 1: Add file1.txt" {
+			t.Fatalf("Expected the first commit, got '%s'", first.Subject)
+		}
+		if first.Insertions != 2 {
+			t.Errorf("Expected 2 insertions listed for the first commit, got %d", first.Insertions)
+		}
+
+		changeset, err := GetChangesForCommit(repoPath, first.Hash)
+		if err != nil {
+			t.Fatalf("Failed to get changeset for root commit: %v", err)
+		}
+		if changeset.IsMerge {
+			t.Error("Expected root commit not to be flagged as a merge")
+		}
+		if len(changeset.Files) != 1 || changeset.Files[0] != "file1.txt" {
+			t.Errorf("Expected files [file1.txt], got %v", changeset.Files)
+		}
+		if !strings.Contains(changeset.Diff, "+This is file 1") {
+			t.Errorf("Expected the root commit's content as added, got '%s'", changeset.Diff)
+		}
+
+		stats, err := GetCommitFileStats(repoPath, first.Hash)
+		if err != nil {
+			t.Fatalf("Failed to get file stats for root commit: %v", err)
+		}
+		if len(stats) != 1 || stats[0].Insertions != 2 {
+			t.Errorf("Expected 2 insertions in file1.txt, got %+v", stats)
+		}
+	})
+
+	t.Run("Merge commit", func(t *testing.T) {
+		repoPath := createTestRepo(t)
+
+		git := func(args ...string) {
+			t.Helper()
+			if output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v: %s", args, err, output)
+			}
+		}
+		git("checkout", "-q", "-b", "feature")
+		if err := os.WriteFile(filepath.Join(repoPath, "feature.txt"), []byte("feature\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		git("add", "feature.txt")
+		git("commit", "-q", "-m", "Add feature")
+		git("checkout", "-q", "-")
+		git("merge", "-q", "--no-ff", "feature", "-m", "Merge branch 'feature'")
+
+		changeset, err := GetChangesForCommit(repoPath, "HEAD")
+		if err != nil {
+			t.Fatalf("Failed to get changeset: %v", err)
+		}
+		if !changeset.IsMerge {
+			t.Error("Expected merge commit to be flagged as a merge")
+		}
+		if changeset.Subject != "Merge branch 'feature'" {
+			t.Errorf("Expected subject \"Merge branch 'feature'\", got '%s'", changeset.Subject)
+		}
+		if len(changeset.Files) != 1 || changeset.Files[0] != "feature.txt" {
+			t.Errorf("Expected files [feature.txt], got %v", changeset.Files)
+		}
+		if !strings.Contains(changeset.Diff, "+feature") {
+			t.Errorf("Expected diff against the first parent, got '%s'", changeset.Diff)
+		}
+
+		parent, err := GetChangesForCommit(repoPath, "HEAD^2")
+		if err != nil {
+			t.Fatalf("Failed to get changeset: %v", err)
+		}
+		if parent.IsMerge {
+			t.Error("Expected a regular commit not to be flagged as a merge")
+		}
+	})
+}
+
+func TestGetChangesBetweenCommits(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	page, err := GetCommitLogs(repoPath, 20, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs: %v", err)
+	}
+
+	// Commits are newest first: compare "Commit 17" to "Commit 20"
+	from := page.Commits[3]
+	to := page.Commits[0]
+
+	changeset, err := GetChangesBetweenCommits(repoPath, from.Hash, to.Hash)
+	if err != nil {
+		t.Fatalf("Failed to get changes between commits: %v", err)
+	}
+
+	expectedFiles := []string{"file18.txt", "file19.txt", "file20.txt"}
+	if len(changeset.Files) != len(expectedFiles) {
+		t.Fatalf("Expected files %v, got %v", expectedFiles, changeset.Files)
+	}
+	for i, file := range expectedFiles {
+		if changeset.Files[i] != file {
+			t.Errorf("Expected file %d to be '%s', got '%s'", i, file, changeset.Files[i])
+		}
+	}
+
+	if changeset.Diff == "" {
+		t.Error("Expected non-empty diff")
+	}
+	if changeset.Author != "Test User" {
+		t.Errorf("Expected author 'Test User', got '%s'", changeset.Author)
+	}
+	if changeset.CommitHash != from.Hash+".."+to.Hash {
+		t.Errorf("Expected commit hash '%s..%s', got '%s'", from.Hash, to.Hash, changeset.CommitHash)
+	}
+	for _, subject := range []string{"Commit 18: Add file18.txt", "Commit 19: Add file19.txt", "Commit 20: Add file20.txt"} {
+		if !strings.Contains(changeset.Body, subject) {
+			t.Errorf("Expected body to list '%s', got '%s'", subject, changeset.Body)
+		}
+	}
+}
+
+func TestGetCommitLogsWithoutCommits(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := exec.Command("git", "-C", repoPath, "init").Run(); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+
+	if HasCommits(repoPath) {
+		t.Error("Expected a new repository to have no commits")
+	}
+	page, err := GetCommitLogs(repoPath, 10, 1)
+	if err != nil {
+		t.Fatalf("Expected an empty page, got %v", err)
+	}
+	if len(page.Commits) != 0 || page.Total != 0 || page.HasMore {
+		t.Errorf("Expected no commits, got %+v", page)
+	}
+
+	if !HasCommits(createTestRepo(t)) {
+		t.Error("Expected the test repository to have commits")
+	}
+}
+
+func TestGetCommitLogsInWorktree(t *testing.T) {
+	repoPath := createTestRepo(t)
+	worktree := filepath.Join(t.TempDir(), "worktree")
+	if out, err := exec.Command("git", "-C", repoPath, "worktree", "add", "-b", "feature", worktree).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add worktree: %v\n%s", err, out)
+	}
+	subDir := filepath.Join(worktree, "nested")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	gitRoot, isGit, err := GetGitDirectory(subDir)
+	if err != nil || !isGit {
+		t.Fatalf("Expected the worktree to be found, got %v, %v", isGit, err)
+	}
+	if gitRoot != worktree {
+		t.Errorf("Expected git root '%s', got '%s'", worktree, gitRoot)
+	}
+	gitDir, err := ResolveGitDir(gitRoot)
+	if err != nil {
+		t.Fatalf("Failed to resolve the worktree's git dir: %v", err)
+	}
+	if !strings.Contains(filepath.ToSlash(gitDir), ".git/worktrees/") {
+		t.Errorf("Expected the git dir under .git/worktrees, got '%s'", gitDir)
+	}
+
+	page, err := GetCommitLogs(subDir, 5, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs in worktree: %v", err)
+	}
+	if page.Total != 20 || len(page.Commits) != 5 {
+		t.Errorf("Expected 5 of 20 commits, got %d of %d", len(page.Commits), page.Total)
+	}
+}
+
+func TestGetCommitLogsWithPath(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	page, err := GetCommitLogsWithOptions(repoPath, CommitLogOptions{Path: "file3.txt"}, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs for path: %v", err)
+	}
+
+	if len(page.Commits) != 1 {
+		t.Fatalf("Expected 1 commit touching file3.txt, got %d", len(page.Commits))
+	}
+	if page.Total != 1 {
+		t.Errorf("Expected Total 1, got %d", page.Total)
+	}
+	if page.Commits[0].Subject != "Commit 3: Add file3.txt" {
+		t.Errorf("Expected 'Commit 3: Add file3.txt', got '%s'", page.Commits[0].Subject)
+	}
+}
+
+func TestGetCommitLogsFollowingRenames(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	for _, args := range [][]string{
+		{"mv", "file3.txt", "renamed.txt"},
+		{"commit", "-m", "Rename file3.txt"},
+	} {
+		if err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).Run(); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	page, err := GetCommitLogsWithOptions(repoPath, CommitLogOptions{Path: "renamed.txt"}, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs for path: %v", err)
+	}
+	if len(page.Commits) != 1 || page.Total != 1 {
+		t.Errorf("Expected only the rename without following, got %d (total %d)", len(page.Commits), page.Total)
+	}
+
+	page, err = GetCommitLogsWithOptions(repoPath, CommitLogOptions{Path: "renamed.txt", Follow: true}, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs following renames: %v", err)
+	}
+	if len(page.Commits) != 2 || page.Total != 2 {
+		t.Fatalf("Expected the rename and the original commit, got %d (total %d)", len(page.Commits), page.Total)
+	}
+	if page.Commits[1].Subject != "Commit 3: Add file3.txt" {
+		t.Errorf("Expected the commit adding file3.txt, got '%s'", page.Commits[1].Subject)
+	}
+}
+
+func TestGetCommitLogsWithRange(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	if err := ValidateRevisionRange(repoPath, "HEAD~5..HEAD"); err != nil {
+		t.Fatalf("Expected HEAD~5..HEAD to be valid, got %v", err)
+	}
+	for _, rev := range []string{"nope..HEAD", "--all", ""} {
+		if err := ValidateRevisionRange(repoPath, rev); err == nil {
+			t.Errorf("Expected %q to be rejected", rev)
+		}
+	}
+
+	page, err := GetCommitLogsWithOptions(repoPath, CommitLogOptions{Range: "HEAD~5..HEAD"}, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs for range: %v", err)
+	}
+	if len(page.Commits) != 5 || page.Total != 5 {
+		t.Fatalf("Expected 5 commits in range, got %d (total %d)", len(page.Commits), page.Total)
+	}
+	if page.Commits[4].Subject != "Commit 16: Add file16.txt" {
+		t.Errorf("Expected oldest commit in range to be 'Commit 16: Add file16.txt', got '%s'", page.Commits[4].Subject)
+	}
+}
+
+func TestResolveCommit(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	head, err := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD~2").Output()
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD~2: %v", err)
+	}
+	hash, err := ResolveCommit(repoPath, "HEAD~2")
+	if err != nil || hash != strings.TrimSpace(string(head)) {
+		t.Errorf("Expected %s, got %q (%v)", head, hash, err)
+	}
+
+	for _, rev := range []string{"nope", "--all", "", "HEAD:file1.txt"} {
+		if _, err := ResolveCommit(repoPath, rev); err == nil {
+			t.Errorf("Expected %q to be rejected", rev)
+		}
+	}
+}
+
+func TestGetCommitLogsWithRef(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	if err := exec.Command("git", "-C", repoPath, "branch", "feature", "HEAD~5").Run(); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+
+	branches, err := ListBranches(repoPath)
+	if err != nil {
+		t.Fatalf("Failed to list branches: %v", err)
+	}
+	current := CurrentBranch(repoPath)
+	if current == "" {
+		t.Fatal("Expected a checked out branch")
+	}
+	if len(branches) != 2 || !slices.Contains(branches, "feature") || !slices.Contains(branches, current) {
+		t.Fatalf("Expected branches feature and %s, got %v", current, branches)
+	}
+
+	page, err := GetCommitLogsWithOptions(repoPath, CommitLogOptions{Ref: "feature"}, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs for ref: %v", err)
+	}
+	if page.Total != 15 {
+		t.Fatalf("Expected 15 commits on feature, got %d", page.Total)
+	}
+	if page.Commits[0].Subject != "Commit 15: Add file15.txt" {
+		t.Errorf("Expected newest commit on feature to be 'Commit 15: Add file15.txt', got '%s'", page.Commits[0].Subject)
+	}
+}
+
+func TestParseCommitsWithNumstat(t *testing.T) {
+	output := commitStartMarker + "abc123\x00Jane\x00jane@example.com\x001700000000\x00Add logo\x00" + commitEndMarker + "\n" +
+		"12\t3\tREADME.md\n" +
+		"-\t-\tlogo.png\n" +
+		"\n" +
+		commitStartMarker + "def456\x00Jane\x00jane@example.com\x001699990000\x00Initial commit\x00Body text" + commitEndMarker + "\n" +
+		"1\t0\tmain.go\n" +
+		"2\t2\t{old => cmd}/run.go\n"
+
+	commits, err := parseCommits(output)
+	if err != nil {
+		t.Fatalf("parseCommits failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].Insertions != 12 || commits[0].Deletions != 3 {
+		t.Errorf("Expected +12 -3 ignoring the binary file, got +%d -%d", commits[0].Insertions, commits[0].Deletions)
+	}
+	if commits[1].Insertions != 3 || commits[1].Deletions != 2 || commits[1].Body != "Body text" {
+		t.Errorf("Unexpected second commit: %+v", commits[1])
+	}
+	if commits[0].FilesChanged != 2 || commits[0].TopDir != "" {
+		t.Errorf("Expected 2 files at the root, got %d in %q", commits[0].FilesChanged, commits[0].TopDir)
+	}
+	if len(commits[1].Paths) != 2 || commits[1].Paths[1] != "cmd/run.go" {
+		t.Errorf("Expected the renamed file under its new path, got %v", commits[1].Paths)
+	}
+}
+
+func TestTopDirectory(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{"Root files", []string{"README.md", "go.mod"}, ""},
+		{"Mostly one directory", []string{"internal/tui/app.go", "internal/core/git.go", "main.go"}, "internal"},
+		{"Tie keeps the first", []string{"docs/a.md", "cmd/main.go"}, "docs"},
+		{"Rename into a directory", []string{"{old => internal}/git.go", "internal/git_test.go"}, "internal"},
+		{"Rename out of the root", []string{"notes.md => docs/notes.md"}, "docs"},
+		{"No files", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topDirectory(tt.paths); got != tt.expected {
+				t.Errorf("topDirectory(%v) = %q, expected %q", tt.paths, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetCommitLogsWithPipesInMessage(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(repoPath, "split.txt"), []byte("a|b"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoPath, "add", "split.txt").Run(); err != nil {
+		t.Fatalf("Failed to add file: %v", err)
+	}
+	commit := exec.Command("git", "-C", repoPath, "-c", "user.name=Pipe | Person", "commit",
+		"-m", "refactor: split a|b", "-m", "Body with | pipes |")
+	if err := commit.Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	page, err := GetCommitLogs(repoPath, 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to get commit logs: %v", err)
+	}
+
+	got := page.Commits[0]
+	if got.Subject != "refactor: split a|b" {
+		t.Errorf("Expected subject 'refactor: split a|b', got '%s'", got.Subject)
+	}
+	if got.Author != "Pipe | Person" {
+		t.Errorf("Expected author 'Pipe | Person', got '%s'", got.Author)
+	}
+	if got.Email != "test@example.com" {
+		t.Errorf("Expected email 'test@example.com', got '%s'", got.Email)
+	}
+	if got.Body != "Body with | pipes |" {
+		t.Errorf("Expected body 'Body with | pipes |', got '%s'", got.Body)
+	}
+
+	changeset, err := GetChangesForCommit(repoPath, got.Hash)
+	if err != nil {
+		t.Fatalf("Failed to get changeset: %v", err)
+	}
+	if changeset.Author != "Pipe | Person" || changeset.Subject != "refactor: split a|b" {
+		t.Errorf("Unexpected changeset metadata: author '%s', subject '%s'", changeset.Author, changeset.Subject)
+	}
+}
+
+func TestGetWorkingTreeChangeset(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	working, err := GetWorkingTreeCommit(repoPath)
+	if err != nil {
+		t.Fatalf("GetWorkingTreeCommit failed: %v", err)
+	}
+	if working != nil {
+		t.Fatalf("Expected no uncommitted changes in a clean repo, got %+v", working)
+	}
+
+	// One staged and one unstaged change
+	if err := os.WriteFile(filepath.Join(repoPath, "file1.txt"), []byte("staged change\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoPath, "add", "file1.txt").Run(); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "file2.txt"), []byte("unstaged change\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	working, err = GetWorkingTreeCommit(repoPath)
+	if err != nil {
+		t.Fatalf("GetWorkingTreeCommit failed: %v", err)
+	}
+	if working == nil || working.Hash != WorkingTreeHash || working.Insertions != 2 {
+		t.Fatalf("Expected a working tree commit with 2 insertions, got %+v", working)
+	}
+
+	changeset, err := GetChangesForCommit(repoPath, WorkingTreeHash)
+	if err != nil {
+		t.Fatalf("GetChangesForCommit failed: %v", err)
+	}
+	if len(changeset.Files) != 2 {
+		t.Errorf("Expected 2 changed files, got %v", changeset.Files)
+	}
+	if !strings.Contains(changeset.Diff, "staged change") || !strings.Contains(changeset.Diff, "unstaged change") {
+		t.Errorf("Expected diff to include staged and unstaged changes, got:\n%s", changeset.Diff)
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	hunk := func(name string) string {
+		return "@@ -1,3 +1,3 @@\n" + strings.Repeat(" context line in "+name+"\n", 10)
+	}
+	file := func(name string, hunks int) string {
+		diff := fmt.Sprintf("diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", name, name, name, name)
+		for i := 0; i < hunks; i++ {
+			diff += hunk(name)
+		}
+		return diff
+	}
+	diff := file("a.go", 2) + file("b.go", 2)
+
+	if got, truncated := TruncateDiff(diff, EstimateTokenCount(diff)); truncated || got != diff {
+		t.Error("Expected a diff within budget to be left alone")
+	}
+
+	// Room for a.go and the first hunk of b.go: b.go's second hunk is dropped
+	budget := EstimateTokenCount(file("a.go", 2)+file("b.go", 1)) + 10
+	got, truncated := TruncateDiff(diff, budget)
+	if !truncated || got != file("a.go", 2)+file("b.go", 1) {
+		t.Errorf("Expected truncation at b.go's second hunk, got:\n%s", got)
+	}
+
+	// Room for a.go and b.go's header only: b.go is dropped entirely
+	budget = EstimateTokenCount(file("a.go", 2)) + 20
+	got, truncated = TruncateDiff(diff, budget)
+	if !truncated || got != file("a.go", 2) {
+		t.Errorf("Expected truncation before b.go, got:\n%s", got)
+	}
+
+	// Smaller than the first hunk: cut at a line boundary
+	got, truncated = TruncateDiff(diff, 20)
+	if !truncated || !strings.HasSuffix(got, "\n") || len(got) > 80 {
+		t.Errorf("Expected a line boundary cut within 80 bytes, got %q", got)
+	}
+}
+
+func TestParseHashLength(t *testing.T) {
+	tests := []struct {
+		value  string
+		length int
+		ok     bool
+	}{
+		{"10", 10, true},
+		{"4", 4, true},
+		{"no", 40, true},
+		{"64", 40, true},
+		{"3", 0, false},
+		{"0", 0, false},
+		{"-7", 0, false},
+		{"auto", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		length, ok := parseHashLength(tt.value)
+		if length != tt.length || ok != tt.ok {
+			t.Errorf("parseHashLength(%q) = %d, %v, expected %d, %v", tt.value, length, ok, tt.length, tt.ok)
+		}
+	}
+}
+
+func TestHashLength(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	repoPath := t.TempDir()
+	if err := exec.Command("git", "-C", repoPath, "init").Run(); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+
+	t.Setenv("COMMITLORE_HASH_LENGTH", "")
+	if length := HashLength(repoPath); length != DefaultHashLength {
+		t.Errorf("Expected the default length %d, got %d", DefaultHashLength, length)
+	}
+
+	if err := exec.Command("git", "-C", repoPath, "config", "core.abbrev", "12").Run(); err != nil {
+		t.Fatalf("Failed to set core.abbrev: %v", err)
+	}
+	if length := HashLength(repoPath); length != 12 {
+		t.Errorf("Expected core.abbrev's length 12, got %d", length)
+	}
+
+	t.Setenv("COMMITLORE_HASH_LENGTH", "9")
+	if length := HashLength(repoPath); length != 9 {
+		t.Errorf("Expected COMMITLORE_HASH_LENGTH to take precedence, got %d", length)
+	}
+
+	t.Setenv("COMMITLORE_HASH_LENGTH", "short")
+	if length := HashLength(repoPath); length != 12 {
+		t.Errorf("Expected an invalid value to fall back to core.abbrev, got %d", length)
+	}
+
+	t.Setenv("COMMITLORE_HASH_LENGTH", "2")
+	if length := HashLength(repoPath); length != 12 {
+		t.Errorf("Expected an out of range value to fall back to core.abbrev, got %d", length)
+	}
+
+	t.Setenv("COMMITLORE_HASH_LENGTH", "100")
+	if length := HashLength(repoPath); length != 40 {
+		t.Errorf("Expected a long value to be capped at the full hash, got %d", length)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []FileStat
+	}{
+		{
+			name:     "Empty output",
+			output:   "",
+			expected: nil,
+		},
+		{
+			name:   "Text files",
+			output: "12\t3\tinternal/core/git.go\n0\t7\treadme.md\n",
+			expected: []FileStat{
+				{Path: "internal/core/git.go", Insertions: 12, Deletions: 3},
+				{Path: "readme.md", Deletions: 7},
+			},
+		},
+		{
+			name:   "Binary file",
+			output: "-\t-\tassets/logo.png\n4\t1\tmain.go\n",
+			expected: []FileStat{
+				{Path: "assets/logo.png", Binary: true},
+				{Path: "main.go", Insertions: 4, Deletions: 1},
+			},
+		},
+		{
+			name:   "Renames",
+			output: "2\t2\tinternal/{tui => ui}/app.go\n0\t0\told.txt => new.txt\n",
+			expected: []FileStat{
+				{Path: "internal/{tui => ui}/app.go", Insertions: 2, Deletions: 2},
+				{Path: "old.txt => new.txt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseNumstat(tt.output)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetCommitFileStatsWithRename(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	if err := exec.Command("git", "-C", repoPath, "mv", "file1.txt", "renamed.txt").Run(); err != nil {
+		t.Fatalf("Failed to rename file: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoPath, "commit", "-m", "Rename file1").Run(); err != nil {
+		t.Fatalf("Failed to commit rename: %v", err)
+	}
+
+	stats, err := GetCommitFileStats(repoPath, "HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitFileStats failed: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Path != "file1.txt => renamed.txt" || stats[0].Insertions != 0 || stats[0].Deletions != 0 {
+		t.Errorf("Expected a single rename without changed lines, got %+v", stats)
+	}
+}
@@ -0,0 +1,165 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+	_ "modernc.org/sqlite"
+)
+
+// Generation is a piece of generated content along with what produced it
+type Generation struct {
+	ID               int64
+	CreatedAt        time.Time
+	RepoPath         string
+	Topic            string
+	Format           string
+	Provider         string
+	Model            string
+	CommitHashes     []string
+	PromptTokens     int
+	CompletionTokens int
+	Content          string
+}
+
+// Store persists generations in a SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+const schema = `CREATE TABLE IF NOT EXISTS generations (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at        INTEGER NOT NULL,
+	repo_path         TEXT NOT NULL,
+	topic             TEXT NOT NULL,
+	format            TEXT NOT NULL,
+	provider          TEXT NOT NULL,
+	model             TEXT NOT NULL,
+	commit_hashes     TEXT NOT NULL,
+	prompt_tokens     INTEGER NOT NULL,
+	completion_tokens INTEGER NOT NULL,
+	content           TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS generations_created_at ON generations (created_at);`
+
+// DefaultPath returns the location of the history database, commitlore.db in
+// the data directory (~/.commitlore by default)
+func DefaultPath() (string, error) {
+	dataDir, err := core.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "commitlore.db"), nil
+}
+
+// Open opens the database at path, creating it and its schema if needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history schema: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save stores a generation and returns its ID. A zero CreatedAt is set to now.
+func (s *Store) Save(generation Generation) (int64, error) {
+	if generation.CreatedAt.IsZero() {
+		generation.CreatedAt = time.Now()
+	}
+
+	result, err := s.db.Exec(`INSERT INTO generations
+		(created_at, repo_path, topic, format, provider, model, commit_hashes, prompt_tokens, completion_tokens, content)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		generation.CreatedAt.Unix(),
+		generation.RepoPath,
+		generation.Topic,
+		generation.Format,
+		generation.Provider,
+		generation.Model,
+		strings.Join(generation.CommitHashes, ","),
+		generation.PromptTokens,
+		generation.CompletionTokens,
+		generation.Content)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save generation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get generation ID: %w", err)
+	}
+	return id, nil
+}
+
+// List returns up to limit generations, newest first. A non-empty query only
+// matches generations whose topic, format, content or commit hashes contain it.
+func (s *Store) List(query string, limit int) ([]Generation, error) {
+	sqlQuery := `SELECT id, created_at, repo_path, topic, format, provider, model, commit_hashes, prompt_tokens, completion_tokens, content
+		FROM generations`
+	var args []interface{}
+
+	if query != "" {
+		sqlQuery += ` WHERE topic LIKE ? ESCAPE '\' OR format LIKE ? ESCAPE '\' OR content LIKE ? ESCAPE '\' OR commit_hashes LIKE ? ESCAPE '\'`
+		pattern := "%" + escapeLike(query) + "%"
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+
+	sqlQuery += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := s.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query generations: %w", err)
+	}
+	defer rows.Close()
+
+	var generations []Generation
+	for rows.Next() {
+		var generation Generation
+		var createdAt int64
+		var commitHashes string
+		err := rows.Scan(&generation.ID, &createdAt, &generation.RepoPath, &generation.Topic, &generation.Format,
+			&generation.Provider, &generation.Model, &commitHashes, &generation.PromptTokens,
+			&generation.CompletionTokens, &generation.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read generation: %w", err)
+		}
+
+		generation.CreatedAt = time.Unix(createdAt, 0)
+		if commitHashes != "" {
+			generation.CommitHashes = strings.Split(commitHashes, ",")
+		}
+		generations = append(generations, generation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read generations: %w", err)
+	}
+	return generations, nil
+}
+
+// escapeLike escapes the LIKE wildcards in a search term
+func escapeLike(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(term)
+}
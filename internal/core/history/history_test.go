@@ -0,0 +1,58 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreSaveAndList(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "commitlore.db"))
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	first := Generation{
+		CreatedAt:    time.Unix(1700000000, 0),
+		Topic:        "Pagination in the commit listing",
+		Format:       "Blog Article",
+		CommitHashes: []string{"abc1234", "def5678"},
+		PromptTokens: 1200,
+		Content:      "We added 100% more pages",
+	}
+	second := Generation{
+		CreatedAt: time.Unix(1700000100, 0),
+		Topic:     "Streaming prompts over stdin",
+		Format:    "Twitter Thread",
+		Content:   "A thread about ARG_MAX",
+	}
+
+	for _, generation := range []Generation{first, second} {
+		if _, err := store.Save(generation); err != nil {
+			t.Fatalf("Failed to save generation: %v", err)
+		}
+	}
+
+	all, err := store.List("", 10)
+	if err != nil {
+		t.Fatalf("Failed to list generations: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 generations, got %d", len(all))
+	}
+	if all[0].Topic != second.Topic {
+		t.Errorf("Expected newest generation first, got %q", all[0].Topic)
+	}
+	if len(all[1].CommitHashes) != 2 || all[1].CommitHashes[1] != "def5678" {
+		t.Errorf("Expected commit hashes to round trip, got %v", all[1].CommitHashes)
+	}
+
+	matches, err := store.List("100%", 10)
+	if err != nil {
+		t.Fatalf("Failed to search generations: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Topic != first.Topic {
+		t.Errorf("Expected search to match only the first generation, got %d results", len(matches))
+	}
+}
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFileName is the file at the repository root listing paths, in
+// gitignore syntax, whose changes are left out of diffs sent to the LLM
+const IgnoreFileName = ".commitloreignore"
+
+// IgnoreMatcher matches repository paths against the patterns of an ignore file
+type IgnoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// ignorePattern is a single compiled line of an ignore file
+type ignorePattern struct {
+	re *regexp.Regexp
+	// negate re-includes paths matched by an earlier pattern
+	negate bool
+	// dirOnly patterns end in "/" and only match directories
+	dirOnly bool
+	// anchored patterns contain a "/" and match from the repository root,
+	// others match a file or directory name at any depth
+	anchored bool
+}
+
+// LoadIgnoreFile reads the ignore file at the root of the repository. It
+// returns nil, and no error, when the repository has no ignore file.
+func LoadIgnoreFile(repoRoot string) (*IgnoreMatcher, error) {
+	file, err := os.Open(filepath.Join(repoRoot, IgnoreFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", IgnoreFileName, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFileName, err)
+	}
+
+	return NewIgnoreMatcher(lines), nil
+}
+
+// NewIgnoreMatcher compiles gitignore-style pattern lines. Blank lines and
+// lines starting with # are skipped.
+func NewIgnoreMatcher(lines []string) *IgnoreMatcher {
+	matcher := &IgnoreMatcher{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var pattern ignorePattern
+		if strings.HasPrefix(line, "!") {
+			pattern.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			pattern.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		pattern.re = globToRegexp(line)
+		matcher.patterns = append(matcher.patterns, pattern)
+	}
+	return matcher
+}
+
+// Match reports whether a slash-separated path relative to the repository
+// root is ignored. As in gitignore, the last matching pattern wins and a
+// matched directory ignores everything under it.
+func (m *IgnoreMatcher) Match(path string) bool {
+	if m == nil {
+		return false
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	ignored := false
+	for _, pattern := range m.patterns {
+		if pattern.matches(parts) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// matches checks the pattern against the path and each of its parent directories
+func (p ignorePattern) matches(parts []string) bool {
+	for i := range parts {
+		isDir := i < len(parts)-1
+		if p.dirOnly && !isDir {
+			continue
+		}
+
+		candidate := parts[i]
+		if p.anchored {
+			candidate = strings.Join(parts[:i+1], "/")
+		}
+		if p.re.MatchString(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts a gitignore glob to an anchored regular expression.
+// "*" and "?" don't cross directories, "**" does.
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**"):
+			b.WriteString("(?:/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		// A malformed character class, match it literally instead
+		return regexp.MustCompile("^" + regexp.QuoteMeta(glob) + "$")
+	}
+	return re
+}
+
+// FilterFiles returns the files that are not ignored
+func (m *IgnoreMatcher) FilterFiles(files []string) []string {
+	if m == nil {
+		return files
+	}
+
+	kept := []string{}
+	for _, file := range files {
+		if !m.Match(file) {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+// FilterDiff drops the sections of a git diff that belong to ignored files
+func (m *IgnoreMatcher) FilterDiff(diff string) string {
+	if m == nil || diff == "" {
+		return diff
+	}
+
+	var b strings.Builder
+	keep := true
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if path, ok := diffHeaderPath(line); ok {
+			keep = !m.Match(path)
+		}
+		if keep {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+// diffHeaderPath extracts the file path from a "diff --git a/x b/x" or
+// "diff --cc x" line, reporting whether the line starts a file section
+func diffHeaderPath(line string) (string, bool) {
+	line = strings.TrimRight(line, "\n")
+	if rest, ok := strings.CutPrefix(line, "diff --cc "); ok {
+		return rest, true
+	}
+	if rest, ok := strings.CutPrefix(line, "diff --combined "); ok {
+		return rest, true
+	}
+	rest, ok := strings.CutPrefix(line, "diff --git ")
+	if !ok {
+		return "", false
+	}
+	if index := strings.LastIndex(rest, " b/"); index >= 0 {
+		return rest[index+len(" b/"):], true
+	}
+	return strings.TrimPrefix(rest, "a/"), true
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	matcher := NewIgnoreMatcher([]string{
+		"# generated files",
+		"*.lock",
+		"dist/",
+		"/vendor",
+		"docs/**/*.svg",
+		"*.pb.go",
+		"!keep.pb.go",
+	})
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"go.lock", true},
+		{"web/yarn.lock", true},
+		{"dist/app.js", true},
+		{"web/dist/app.js", true},
+		{"dist", false},
+		{"vendor/lib/a.go", true},
+		{"web/vendor/a.go", false},
+		{"docs/img/arch.svg", true},
+		{"docs/arch.svg", true},
+		{"img/arch.svg", false},
+		{"api/service.pb.go", true},
+		{"api/keep.pb.go", false},
+		{"main.go", false},
+	}
+
+	for _, test := range tests {
+		if got := matcher.Match(test.path); got != test.ignored {
+			t.Errorf("Match(%q) = %v, expected %v", test.path, got, test.ignored)
+		}
+	}
+}
+
+func TestGetChangesForCommitRespectsIgnoreFile(t *testing.T) {
+	repoPath := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	run("init")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+	for name, content := range map[string]string{"main.go": "package main\n", "go.sum": "checksum\n"} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	run("add", ".")
+	run("commit", "-m", "Add main and checksums")
+
+	if err := os.WriteFile(filepath.Join(repoPath, IgnoreFileName), []byte("go.sum\n"), 0644); err != nil {
+		t.Fatalf("Failed to write ignore file: %v", err)
+	}
+
+	changeset, err := GetChangesForCommit(repoPath, "HEAD")
+	if err != nil {
+		t.Fatalf("GetChangesForCommit failed: %v", err)
+	}
+	if len(changeset.Files) != 1 || changeset.Files[0] != "main.go" {
+		t.Errorf("Expected only main.go in files, got %v", changeset.Files)
+	}
+	if strings.Contains(changeset.Diff, "go.sum") || !strings.Contains(changeset.Diff, "main.go") {
+		t.Errorf("Expected go.sum to be filtered out of the diff, got:\n%s", changeset.Diff)
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// extensionLanguages maps file extensions to the language they are written in
+var extensionLanguages = map[string]string{
+	".go":     "Go",
+	".py":     "Python",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".mjs":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".rs":     "Rust",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".swift":  "Swift",
+	".rb":     "Ruby",
+	".php":    "PHP",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".scala":  "Scala",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".sql":    "SQL",
+	".html":   "HTML",
+	".css":    "CSS",
+	".scss":   "CSS",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+	".yaml":   "YAML",
+	".yml":    "YAML",
+	".json":   "JSON",
+	".toml":   "TOML",
+	".xml":    "XML",
+	".proto":  "Protocol Buffers",
+	".tf":     "Terraform",
+	".md":     "Markdown",
+	".mdx":    "Markdown",
+}
+
+// fileNameLanguages maps well-known file names without a telling extension
+var fileNameLanguages = map[string]string{
+	"Dockerfile":  "Dockerfile",
+	"Makefile":    "Makefile",
+	"go.mod":      "Go",
+	"go.sum":      "Go",
+	"Gemfile":     "Ruby",
+	"Rakefile":    "Ruby",
+	"Jenkinsfile": "Groovy",
+}
+
+// Language returns the language of the file at path from its name or
+// extension, or an empty string when it isn't recognized
+func Language(filePath string) string {
+	name := path.Base(filePath)
+	if language, ok := fileNameLanguages[name]; ok {
+		return language
+	}
+	return extensionLanguages[strings.ToLower(path.Ext(name))]
+}
+
+// LanguageCount is the number of changed files in a language
+type LanguageCount struct {
+	Language string
+	Files    int
+}
+
+// LanguageBreakdown counts the distinct files in paths by language, most
+// files first. Unrecognized files are counted under "Other", listed last.
+func LanguageBreakdown(paths []string) []LanguageCount {
+	seen := make(map[string]bool)
+	counts := make(map[string]int)
+	for _, filePath := range paths {
+		if seen[filePath] {
+			continue
+		}
+		seen[filePath] = true
+
+		language := Language(filePath)
+		if language == "" {
+			language = "Other"
+		}
+		counts[language]++
+	}
+
+	breakdown := make([]LanguageCount, 0, len(counts))
+	for language, files := range counts {
+		breakdown = append(breakdown, LanguageCount{Language: language, Files: files})
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		a, b := breakdown[i], breakdown[j]
+		if (a.Language == "Other") != (b.Language == "Other") {
+			return b.Language == "Other"
+		}
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Language < b.Language
+	})
+	return breakdown
+}
+
+// FormatLanguageBreakdown renders a breakdown like "Go: 8 files, YAML: 1 file"
+func FormatLanguageBreakdown(breakdown []LanguageCount) string {
+	parts := make([]string, 0, len(breakdown))
+	for _, count := range breakdown {
+		unit := "files"
+		if count.Files == 1 {
+			unit = "file"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d %s", count.Language, count.Files, unit))
+	}
+	return strings.Join(parts, ", ")
+}
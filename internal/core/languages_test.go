@@ -0,0 +1,38 @@
+package core
+
+import "testing"
+
+func TestLanguage(t *testing.T) {
+	tests := map[string]string{
+		"internal/core/git.go":     "Go",
+		"web/App.TSX":              "TypeScript",
+		".github/workflows/ci.yml": "YAML",
+		"build/Dockerfile":         "Dockerfile",
+		"go.sum":                   "Go",
+		"LICENSE":                  "",
+		"assets/logo.png":          "",
+	}
+	for filePath, expected := range tests {
+		if language := Language(filePath); language != expected {
+			t.Errorf("Language(%q) = %q, expected %q", filePath, language, expected)
+		}
+	}
+}
+
+func TestLanguageBreakdown(t *testing.T) {
+	paths := []string{
+		"main.go", "internal/core/git.go", "internal/tui/app.go",
+		"config.yaml", "LICENSE",
+		// Files changed in several commits count once
+		"main.go",
+	}
+
+	breakdown := LanguageBreakdown(paths)
+	if got := FormatLanguageBreakdown(breakdown); got != "Go: 3 files, YAML: 1 file, Other: 1 file" {
+		t.Errorf("Unexpected breakdown %q", got)
+	}
+
+	if got := FormatLanguageBreakdown(LanguageBreakdown(nil)); got != "" {
+		t.Errorf("Expected an empty breakdown, got %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,12 +12,21 @@ import (
 type LLMResponse struct {
 	Content string
 	Error   error
+	// Usage is the token usage reported by the API, zero if not reported
+	Usage Usage
 }
 
 // LLMResponseMsg is a Bubble Tea message for LLM responses
 type LLMResponseMsg struct {
 	Content string
 	Error   string
+	// ContextLengthExceeded is set when the prompt didn't fit the model's context window
+	ContextLengthExceeded bool
+	// Canceled is set when the request's context was canceled by the caller,
+	// so the response belongs to an abandoned request
+	Canceled bool
+	// Usage is the token usage reported by the API, zero if not reported
+	Usage Usage
 }
 
 // AsyncLLMWrapper wraps LLM calls to run them asynchronously with channels
@@ -36,6 +46,11 @@ func NewAsyncLLMWrapper(provider LLMProvider, timeout time.Duration) *AsyncLLMWr
 	}
 }
 
+// Timeout returns how long a call may run before it is abandoned
+func (a *AsyncLLMWrapper) Timeout() time.Duration {
+	return a.timeout
+}
+
 // GenerateContentAsync runs GenerateContent in a goroutine and sends response to channel
 func (a *AsyncLLMWrapper) GenerateContentAsync(ctx context.Context, prompt string, responseChan chan<- LLMResponse) {
 	go func() {
@@ -45,16 +60,9 @@ func (a *AsyncLLMWrapper) GenerateContentAsync(ctx context.Context, prompt strin
 		timeoutCtx, cancel := context.WithTimeout(ctx, a.timeout)
 		defer cancel()
 		
-		content, err := a.provider.GenerateContent(timeoutCtx, prompt)
-		
-		select {
-		case responseChan <- LLMResponse{Content: content, Error: err}:
-		case <-timeoutCtx.Done():
-			// Context cancelled or timed out
-			if timeoutCtx.Err() == context.DeadlineExceeded {
-				responseChan <- LLMResponse{Content: "", Error: context.DeadlineExceeded}
-			}
-		}
+		var usage Usage
+		content, err := a.provider.GenerateContent(withUsage(timeoutCtx, &usage), prompt)
+		responseChan <- finishResponse(timeoutCtx, content, err, usage)
 	}()
 }
 
@@ -67,19 +75,23 @@ func (a *AsyncLLMWrapper) GenerateContentWithSystemPromptAsync(ctx context.Conte
 		timeoutCtx, cancel := context.WithTimeout(ctx, a.timeout)
 		defer cancel()
 		
-		content, err := a.provider.GenerateContentWithSystemPrompt(timeoutCtx, systemPrompt, userPrompt)
-		
-		select {
-		case responseChan <- LLMResponse{Content: content, Error: err}:
-		case <-timeoutCtx.Done():
-			// Context cancelled or timed out
-			if timeoutCtx.Err() == context.DeadlineExceeded {
-				responseChan <- LLMResponse{Content: "", Error: context.DeadlineExceeded}
-			}
-		}
+		var usage Usage
+		content, err := a.provider.GenerateContentWithSystemPrompt(withUsage(timeoutCtx, &usage), systemPrompt, userPrompt)
+		responseChan <- finishResponse(timeoutCtx, content, err, usage)
 	}()
 }
 
+// finishResponse builds the response for a finished call. Once ctx is done,
+// because it timed out or the caller canceled it, its error is reported even
+// if the provider returned content, so an abandoned request never looks like
+// a fresh result. The channel is buffered, so sending never blocks.
+func finishResponse(ctx context.Context, content string, err error, usage Usage) LLMResponse {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return LLMResponse{Error: ctxErr}
+	}
+	return LLMResponse{Content: content, Error: err, Usage: usage}
+}
+
 // WaitForLLMResponse creates a tea.Cmd that waits for LLM response on a channel
 func WaitForLLMResponse(responseChan <-chan LLMResponse) tea.Cmd {
 	return func() tea.Msg {
@@ -91,8 +103,11 @@ func WaitForLLMResponse(responseChan <-chan LLMResponse) tea.Cmd {
 		}
 		
 		return LLMResponseMsg{
-			Content: response.Content,
-			Error:   errorMsg,
+			Content:               response.Content,
+			Error:                 errorMsg,
+			ContextLengthExceeded: errors.Is(response.Error, ErrContextLengthExceeded),
+			Canceled:              errors.Is(response.Error, context.Canceled),
+			Usage:                 response.Usage,
 		}
 	}
 }
@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAsyncCancel(t *testing.T) {
+	wrapper := NewAsyncLLMWrapper(NewMockProvider(MockOptions{Latency: time.Hour}), time.Hour)
+	responseChan := CreateLLMResponseChannel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wrapper.GenerateContentAsync(ctx, "prompt", responseChan)
+	cancel()
+
+	select {
+	case response := <-responseChan:
+		msg := WaitForLLMResponse(func() chan LLMResponse {
+			replay := CreateLLMResponseChannel()
+			replay <- response
+			return replay
+		}())().(LLMResponseMsg)
+		if !msg.Canceled || msg.Content != "" {
+			t.Errorf("Expected a canceled response, got %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a response once the request was canceled")
+	}
+}
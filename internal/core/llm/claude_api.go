@@ -5,28 +5,97 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 )
 
 // Compile-time interface compliance check
-var _ LLMProvider = (*ClaudeClient)(nil)
+var (
+	_ LLMProvider  = (*ClaudeClient)(nil)
+	_ TokenCounter = (*ClaudeClient)(nil)
+)
+
+// DefaultClaudeBaseURL is the public Anthropic API endpoint
+const DefaultClaudeBaseURL = "https://api.anthropic.com/v1"
+
+// DefaultClaudeModel is the model used when none is configured
+const DefaultClaudeModel = "claude-3-5-sonnet-20241022"
+
+// DefaultAnthropicVersion is the anthropic-version header sent when none is configured
+const DefaultAnthropicVersion = "2023-06-01"
+
+// ClaudeClientOptions customises how the Claude API client talks to the API.
+// Zero values fall back to the defaults.
+type ClaudeClientOptions struct {
+	// Model is the Claude model to use, e.g. a Haiku model for cheap requests
+	Model string
+	// BaseURL points the client at a proxy or API gateway
+	BaseURL string
+	// APIVersion is sent as the anthropic-version header
+	APIVersion string
+	// Headers are extra headers sent with every request, e.g. anthropic-beta
+	Headers map[string]string
+	// MaxTokens caps the length of a response
+	MaxTokens int
+	// Timeout caps each HTTP request. Zero leaves cancellation to the request
+	// context, whose deadline AsyncLLMWrapper sets.
+	Timeout time.Duration
+	// Temperature is the default sampling temperature, nil for the API's default
+	Temperature *float64
+}
+
+// NewClaudeClient creates a new Claude API client for the given model. An
+// empty model uses DefaultClaudeModel.
+func NewClaudeClient(apiKey, model string) *ClaudeClient {
+	return NewClaudeClientWithOptions(apiKey, ClaudeClientOptions{Model: model})
+}
+
+// NewClaudeClientWithBaseURL creates a new Claude API client that talks to the
+// given base URL, e.g. a proxy or API gateway. An empty baseURL uses the public endpoint.
+func NewClaudeClientWithBaseURL(apiKey, baseURL string) *ClaudeClient {
+	return NewClaudeClientWithOptions(apiKey, ClaudeClientOptions{BaseURL: baseURL})
+}
+
+// NewClaudeClientWithOptions creates a new Claude API client with the given options
+func NewClaudeClientWithOptions(apiKey string, opts ClaudeClientOptions) *ClaudeClient {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultClaudeBaseURL
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	apiVersion := opts.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAnthropicVersion
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = DefaultClaudeModel
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
 
-// NewClaudeClient creates a new Claude API client
-func NewClaudeClient(apiKey string) *ClaudeClient {
 	logger := core.GetLogger()
-	logger.Info("Creating new Claude API client", "provider", "claude-api", "model", "claude-3-5-sonnet-20241022")
+	logger.Info("Creating new Claude API client", "provider", "claude-api", "model", model, "base_url", baseURL, "anthropic_version", apiVersion, "extra_headers", len(opts.Headers), "max_tokens", maxTokens, "timeout", opts.Timeout)
 	
 	return &ClaudeClient{
 		apiKey: apiKey,
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout: opts.Timeout,
 		},
-		baseURL: "https://api.anthropic.com/v1",
-		model:   "claude-3-5-sonnet-20241022",
+		baseURL:     baseURL,
+		model:       model,
+		apiVersion:  apiVersion,
+		headers:     opts.Headers,
+		maxTokens:   maxTokens,
+		temperature: opts.Temperature,
 	}
 }
 
@@ -50,13 +119,14 @@ func (c *ClaudeClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	start := time.Now()
 	req := ClaudeRequest{
 		Model:     c.model,
-		MaxTokens: 4000,
+		MaxTokens: c.maxTokens,
 		Messages: []ClaudeMessage{
 			{
 				Role:    "user",
 				Content: userPrompt,
 			},
 		},
+		Temperature: requestTemperature(ctx, c.temperature),
 	}
 
 	if systemPrompt != "" {
@@ -71,48 +141,34 @@ func (c *ClaudeClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	
 	logger.Debug("Marshaled request", "request_size", len(reqBody))
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/messages", bytes.NewBuffer(reqBody))
-	if err != nil {
-		logger.Error("Failed to create HTTP request", "provider", "claude-api", "error", err, "url", c.baseURL+"/messages")
-		return "", fmt.Errorf("failed to create request: %w", err)
+	newRequest := func() (*http.Request, error) {
+		return c.newRequest(ctx, "/messages", reqBody)
 	}
-	
-	logger.Debug("Created HTTP request", "url", c.baseURL+"/messages", "method", "POST")
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("anthropic-version", "2023-06-01")
 
-	logger.Debug("Making HTTP request to Claude API")
-	resp, err := c.httpClient.Do(httpReq)
+	logger.Debug("Making HTTP request to Claude API", "url", c.baseURL+"/messages", "method", "POST")
+	statusCode, respBody, err := doWithRetry(ctx, c.httpClient, "claude-api", newRequest)
 	if err != nil {
 		logger.Error("Failed to make HTTP request to Claude API", "provider", "claude-api", "error", err, "duration", time.Since(start))
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 	
-	logger.Debug("Received HTTP response", "status_code", resp.StatusCode, "duration", time.Since(start))
+	logger.Debug("Received HTTP response", "status_code", statusCode, "response_size", len(respBody), "duration", time.Since(start))
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error("Failed to read response body", "provider", "claude-api", "error", err)
-		return "", fmt.Errorf("failed to read response: %w", err)
-	}
-	
-	logger.Debug("Read response body", "response_size", len(respBody))
-
-	if resp.StatusCode != http.StatusOK {
+	if statusCode != http.StatusOK {
 		logger.Error("Claude API request failed", 
 			"provider", "claude-api",
-			"status_code", resp.StatusCode, 
-			"response_body", string(respBody),
+			"status_code", statusCode, 
+			"response_body", core.LogExcerpt(string(respBody)),
 			"duration", time.Since(start))
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		if isContextLengthMessage(string(respBody)) {
+			return "", fmt.Errorf("%w: API request failed with status %d: %s", ErrContextLengthExceeded, statusCode, string(respBody))
+		}
+		return "", fmt.Errorf("API request failed with status %d: %s", statusCode, string(respBody))
 	}
 
 	var claudeResp ClaudeResponse
 	if err := json.Unmarshal(respBody, &claudeResp); err != nil {
-		logger.Error("Failed to unmarshal Claude API response", "provider", "claude-api", "error", err, "response_body", string(respBody))
+		logger.Error("Failed to unmarshal Claude API response", "provider", "claude-api", "error", err, "response_body", core.LogExcerpt(string(respBody)))
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 	
@@ -124,6 +180,7 @@ func (c *ClaudeClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	}
 
 	responseText := claudeResp.Content[0].Text
+	recordUsage(ctx, claudeResp.Usage.InputTokens, claudeResp.Usage.OutputTokens)
 	logger.Info("Successfully generated content with Claude API", 
 		"provider", "claude-api",
 		"response_length", len(responseText),
@@ -133,4 +190,72 @@ func (c *ClaudeClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 		"output_tokens", claudeResp.Usage.OutputTokens)
 	
 	return responseText, nil
-}
\ No newline at end of file
+}
+
+// newRequest builds a POST to an API path with the auth and version headers
+func (c *ClaudeClient) newRequest(ctx context.Context, path string, body []byte) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", c.apiKey)
+	httpReq.Header.Set("anthropic-version", c.apiVersion)
+	for name, value := range c.headers {
+		httpReq.Header.Set(name, value)
+	}
+	return httpReq, nil
+}
+
+// CountTokens counts the tokens text takes up as a user message to the
+// configured model, using the API's count_tokens endpoint. Counting is free
+// but rate limited, so callers should cache the result.
+func (c *ClaudeClient) CountTokens(ctx context.Context, text string) (int, error) {
+	logger := core.GetLogger()
+	start := time.Now()
+
+	reqBody, err := json.Marshal(ClaudeCountTokensRequest{
+		Model:    c.model,
+		Messages: []ClaudeMessage{{Role: "user", Content: text}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	statusCode, respBody, err := doWithRetry(ctx, c.httpClient, "claude-api", func() (*http.Request, error) {
+		return c.newRequest(ctx, "/messages/count_tokens", reqBody)
+	})
+	if err != nil {
+		logger.Warn("Failed to count tokens with Claude API", "provider", "claude-api", "error", err, "duration", time.Since(start))
+		return 0, err
+	}
+	if statusCode != http.StatusOK {
+		logger.Warn("Claude API token count failed", "provider", "claude-api", "status_code", statusCode, "response_body", core.LogExcerpt(string(respBody)))
+		return 0, fmt.Errorf("token count failed with status %d: %s", statusCode, string(respBody))
+	}
+
+	var countResp ClaudeCountTokensResponse
+	if err := json.Unmarshal(respBody, &countResp); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	logger.Debug("Counted tokens with Claude API", "provider", "claude-api", "text_length", len(text), "input_tokens", countResp.InputTokens, "duration", time.Since(start))
+	return countResp.InputTokens, nil
+}
+
+// ModelInfo returns the provider name and the model used for requests
+func (c *ClaudeClient) ModelInfo() (string, string) {
+	return "Claude API", c.model
+}
+
+// MaxTokens returns the cap on the length of a response
+func (c *ClaudeClient) MaxTokens() int {
+	return c.maxTokens
+}
+
+// DefaultTemperature returns the temperature used when a request doesn't set one
+func (c *ClaudeClient) DefaultTemperature() float64 {
+	if c.temperature != nil {
+		return *c.temperature
+	}
+	return claudeDefaultTemperature
+}
@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClaudeClientCountTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/count_tokens" {
+			t.Errorf("Expected the count_tokens endpoint, got %s", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "key" {
+			t.Errorf("Expected the API key header, got %q", r.Header.Get("x-api-key"))
+		}
+		var req ClaudeCountTokensRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Model != "claude-test" || len(req.Messages) != 1 || req.Messages[0].Content != "some diff" {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+		w.Write([]byte(`{"input_tokens":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL, Model: "claude-test"})
+	tokens, err := client.CountTokens(context.Background(), "some diff")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if tokens != 42 {
+		t.Errorf("Expected 42 tokens, got %d", tokens)
+	}
+}
+
+func TestClaudeClientCountTokensError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid model"))
+	}))
+	defer server.Close()
+
+	client := NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL})
+	_, err := client.CountTokens(context.Background(), "some diff")
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Fatalf("Expected a 400 error, got: %v", err)
+	}
+}
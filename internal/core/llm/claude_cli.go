@@ -62,19 +62,22 @@ func (c *ClaudeCLIClient) GenerateContentWithSystemPrompt(ctx context.Context, s
 		"exec_path", c.execPath)
 	
 	start := time.Now()
-	var cmd *exec.Cmd
+	prompt := userPrompt
 	
 	if systemPrompt != "" {
 		// Combine system prompt and user prompt
-		fullPrompt := fmt.Sprintf("System: %s\n\nUser: %s", systemPrompt, userPrompt)
-		logger.Debug("Using system prompt with Claude CLI", "full_prompt_length", len(fullPrompt))
-		cmd = exec.CommandContext(ctx, c.execPath, "--print", "--output-format", "text", fullPrompt)
+		prompt = fmt.Sprintf("System: %s\n\nUser: %s", systemPrompt, userPrompt)
+		logger.Debug("Using system prompt with Claude CLI", "full_prompt_length", len(prompt))
 	} else {
 		logger.Debug("Using user prompt only with Claude CLI")
-		cmd = exec.CommandContext(ctx, c.execPath, "--print", "--output-format", "text", userPrompt)
 	}
 	
-	logger.Debug("Prepared Claude CLI command", "args", cmd.Args)
+	// The prompt is written to stdin rather than passed as an argument so that
+	// large diffs don't run into the kernel's argument size limit (ARG_MAX)
+	cmd := exec.CommandContext(ctx, c.execPath, "--print", "--output-format", "text")
+	cmd.Stdin = strings.NewReader(prompt)
+	
+	logger.Debug("Prepared Claude CLI command", "args", cmd.Args, "stdin_length", len(prompt))
 	
 	// Set environment variables to ensure proper execution
 	cmd.Env = os.Environ()
@@ -92,9 +95,12 @@ func (c *ClaudeCLIClient) GenerateContentWithSystemPrompt(ctx context.Context, s
 		logger.Error("Claude CLI execution failed", 
 			"provider", "claude-cli",
 			"error", err,
-			"stderr", stderr.String(),
+			"stderr", core.LogExcerpt(stderr.String()),
 			"duration", time.Since(start),
 			"command", cmd.Args)
+		if isContextLengthMessage(stderr.String() + stdout.String()) {
+			return "", fmt.Errorf("%w: claude CLI execution failed: %v (stderr: %s)", ErrContextLengthExceeded, err, stderr.String())
+		}
 		return "", fmt.Errorf("claude CLI execution failed: %w (stderr: %s)", err, stderr.String())
 	}
 	
@@ -104,10 +110,14 @@ func (c *ClaudeCLIClient) GenerateContentWithSystemPrompt(ctx context.Context, s
 		"stderr_length", stderr.Len())
 	
 	response := strings.TrimSpace(stdout.String())
+	// The CLI reports an oversized prompt as its output rather than failing
+	if strings.EqualFold(response, "prompt is too long") {
+		return "", fmt.Errorf("%w: %s", ErrContextLengthExceeded, response)
+	}
 	if response == "" {
 		logger.Error("Claude CLI returned empty response", 
 			"provider", "claude-cli",
-			"stderr", stderr.String(),
+			"stderr", core.LogExcerpt(stderr.String()),
 			"duration", time.Since(start))
 		return "", fmt.Errorf("claude CLI returned empty response (stderr: %s)", stderr.String())
 	}
@@ -118,4 +128,10 @@ func (c *ClaudeCLIClient) GenerateContentWithSystemPrompt(ctx context.Context, s
 		"duration", time.Since(start))
 	
 	return response, nil
-}
\ No newline at end of file
+}
+
+// ModelInfo returns the provider name and no model, since the CLI uses its
+// own configured model
+func (c *ClaudeCLIClient) ModelInfo() (string, string) {
+	return "Claude CLI", ""
+}
@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+func TestMain(m *testing.M) {
+	homeDir, err := os.MkdirTemp("", "commitlore-llm-test-*")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", homeDir)
+
+	if err := core.InitLogger(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(homeDir)
+	os.Exit(code)
+}
+
+// newFakeCLIClient creates a ClaudeCLIClient backed by a shell script that
+// runs the given commands
+func newFakeCLIClient(t *testing.T, commands string) *ClaudeCLIClient {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLI relies on a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"+commands+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to write fake CLI: %v", err)
+	}
+
+	return &ClaudeCLIClient{execPath: script}
+}
+
+func TestClaudeCLIClientLargePrompt(t *testing.T) {
+	client := newFakeCLIClient(t, "wc -c")
+
+	// 4MB is well beyond both the per-argument limit (128KB) and the
+	// typical total ARG_MAX (2MB) on Linux
+	prompt := strings.Repeat("a", 4*1024*1024)
+
+	response, err := client.GenerateContent(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("Expected large prompt to succeed, got error: %v", err)
+	}
+
+	received, err := strconv.Atoi(strings.TrimSpace(response))
+	if err != nil {
+		t.Fatalf("Failed to parse fake CLI output %q: %v", response, err)
+	}
+	if received != len(prompt) {
+		t.Errorf("Expected CLI to receive %d bytes on stdin, got %d", len(prompt), received)
+	}
+}
+
+func TestClaudeCLIClientSystemPromptOnStdin(t *testing.T) {
+	client := newFakeCLIClient(t, "wc -c")
+
+	systemPrompt := "You are a helpful assistant"
+	userPrompt := strings.Repeat("b", 256*1024)
+
+	response, err := client.GenerateContentWithSystemPrompt(context.Background(), systemPrompt, userPrompt)
+	if err != nil {
+		t.Fatalf("Expected prompt with system prompt to succeed, got error: %v", err)
+	}
+
+	expected := len("System: " + systemPrompt + "\n\nUser: " + userPrompt)
+	received, err := strconv.Atoi(strings.TrimSpace(response))
+	if err != nil {
+		t.Fatalf("Failed to parse fake CLI output %q: %v", response, err)
+	}
+	if received != expected {
+		t.Errorf("Expected CLI to receive %d bytes on stdin, got %d", expected, received)
+	}
+}
+
+func TestClaudeCLIClientContextLengthError(t *testing.T) {
+	client := newFakeCLIClient(t, "cat >/dev/null\necho 'Prompt is too long'\nexit 1")
+
+	_, err := client.GenerateContent(context.Background(), "prompt")
+	if !errors.Is(err, ErrContextLengthExceeded) {
+		t.Errorf("Expected ErrContextLengthExceeded, got: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// ErrPromptTooLarge is returned by CheckPromptSize for a prompt that won't fit
+// in the model's context window
+var ErrPromptTooLarge = errors.New("prompt is too large for the model's context window")
+
+// modelContextWindows lists context window sizes in tokens by model name prefix
+var modelContextWindows = map[string]int{
+	"claude-":       200000,
+	"gpt-3.5-turbo": 16385,
+	"gpt-4":         8192,
+	"gpt-4-turbo":   128000,
+	"gpt-4o":        128000,
+	"llama2":        4096,
+}
+
+// providerContextWindows lists context window sizes for providers that don't
+// report their model
+var providerContextWindows = map[string]int{
+	"Claude CLI": 200000,
+}
+
+// ContextWindow returns the context window of a model in tokens, matching the
+// longest known model name prefix and falling back to the provider's window.
+// It returns false when the window is unknown.
+func ContextWindow(provider, model string) (int, bool) {
+	if window, ok := longestPrefixMatch(modelContextWindows, model); ok {
+		return window, true
+	}
+	window, ok := providerContextWindows[provider]
+	return window, ok
+}
+
+// MaxTokensReporter is implemented by providers with a configured cap on the
+// length of a response
+type MaxTokensReporter interface {
+	MaxTokens() int
+}
+
+// CheckPromptSize returns an error wrapping ErrPromptTooLarge when a prompt of
+// promptTokens, plus room for a response of maxTokens, exceeds the model's
+// context window. A maxTokens of zero or less leaves room for
+// DefaultMaxTokens. Prompts for models with an unknown window pass.
+func CheckPromptSize(provider, model string, promptTokens, maxTokens int) error {
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	window, ok := ContextWindow(provider, model)
+	if !ok || promptTokens+maxTokens <= window {
+		return nil
+	}
+	return fmt.Errorf("%w: about %s tokens for a %s token window", ErrPromptTooLarge,
+		core.FormatTokenCount(promptTokens), core.FormatTokenCount(window))
+}
@@ -0,0 +1,45 @@
+package llm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		provider string
+		model    string
+		window   int
+		known    bool
+	}{
+		{"Claude API", "claude-sonnet-4-20250514", 200000, true},
+		{"OpenAI API", "gpt-4o-mini", 128000, true},
+		{"OpenAI API", "gpt-4-0613", 8192, true},
+		{"Claude CLI", "", 200000, true},
+		{"Mock", "mock", 0, false},
+	}
+	for _, tt := range tests {
+		window, known := ContextWindow(tt.provider, tt.model)
+		if window != tt.window || known != tt.known {
+			t.Errorf("ContextWindow(%q, %q) = %d, %v, expected %d, %v", tt.provider, tt.model, window, known, tt.window, tt.known)
+		}
+	}
+}
+
+func TestCheckPromptSize(t *testing.T) {
+	if err := CheckPromptSize("OpenAI API", "gpt-4", 1000, 0); err != nil {
+		t.Errorf("Expected a small prompt to fit, got %v", err)
+	}
+	if err := CheckPromptSize("OpenAI API", "gpt-4", 8000, 0); !errors.Is(err, ErrPromptTooLarge) {
+		t.Errorf("Expected ErrPromptTooLarge without room for the response, got %v", err)
+	}
+	if err := CheckPromptSize("OpenAI API", "gpt-4", 7000, 1000); err != nil {
+		t.Errorf("Expected a prompt to fit with room for a configured 1000 token response, got %v", err)
+	}
+	if err := CheckPromptSize("OpenAI API", "gpt-4", 5000, 4000); !errors.Is(err, ErrPromptTooLarge) {
+		t.Errorf("Expected ErrPromptTooLarge without room for a configured 4000 token response, got %v", err)
+	}
+	if err := CheckPromptSize("Mock", "mock", 10000000, 0); err != nil {
+		t.Errorf("Expected unknown models to pass, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrContextLengthExceeded is returned when a prompt is larger than the
+// model's context window
+var ErrContextLengthExceeded = errors.New("prompt exceeds the model's context length")
+
+// contextLengthMarkers are fragments providers use in their error messages
+// when a prompt doesn't fit in the context window
+var contextLengthMarkers = []string{
+	"context_length_exceeded",
+	"maximum context length",
+	"prompt is too long",
+	"context window",
+	"too many tokens",
+}
+
+// isContextLengthMessage reports whether a provider error message describes
+// a prompt that exceeds the context window
+func isContextLengthMessage(message string) bool {
+	lower := strings.ToLower(message)
+	for _, marker := range contextLengthMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,8 +4,21 @@ import (
 	"context"
 )
 
+// DefaultMaxTokens caps the length of a response when no limit is configured
+const DefaultMaxTokens = 4000
+
 // LLMProvider defines the interface for all LLM implementations
 type LLMProvider interface {
 	GenerateContent(ctx context.Context, prompt string) (string, error)
 	GenerateContentWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
-}
\ No newline at end of file
+	// ModelInfo reports the provider's display name and the model it sends
+	// requests to. The model is empty when the provider picks its own.
+	ModelInfo() (provider string, model string)
+}
+
+// TokenCounter is implemented by providers that can count tokens the way
+// their model does, which is more accurate than core.EstimateTokenCount.
+// Counting may call the provider's API.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, text string) (int, error)
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Platform character limits that generated content is checked against
+const (
+	TweetCharLimit    = 280
+	LinkedInCharLimit = 3000
+)
+
+// DefaultLengthTarget returns the character target for a content format, or 0
+// when the format has none. For Twitter threads the target applies to each tweet.
+func DefaultLengthTarget(format string) int {
+	switch format {
+	case ContentFormatTwitterThread:
+		return TweetCharLimit
+	case ContentFormatLinkedInPost:
+		return LinkedInCharLimit
+	default:
+		return 0
+	}
+}
+
+// LengthInstruction returns the prompt instruction asking for content within
+// target characters, or an empty string when target is 0
+func LengthInstruction(format string, target int) string {
+	if target <= 0 {
+		return ""
+	}
+	if format == ContentFormatTwitterThread {
+		return fmt.Sprintf("Keeps every tweet, including its 1/N numbering, under %d characters", target)
+	}
+	return fmt.Sprintf("Stays under %d characters in total", target)
+}
+
+// tweetMarker matches the numbering that starts a tweet, like "1/8", "🧵 2/N"
+// or "**Tweet 3/8**"
+var tweetMarker = regexp.MustCompile(`(?i)^[^\p{L}\p{N}]*(?:tweet\s*)?\d+\s*/\s*(?:\d+|n)\b`)
+
+// SplitTweets splits a generated thread into its tweets. Tweets are expected to
+// start with their 1/N numbering; threads without numbering are split on
+// blank lines.
+func SplitTweets(content string) []string {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+
+	var tweets []string
+	var current []string
+	numbered := false
+	flush := func() {
+		if tweet := strings.TrimSpace(strings.Join(current, "\n")); tweet != "" {
+			tweets = append(tweets, tweet)
+		}
+		current = nil
+	}
+	for _, line := range lines {
+		if tweetMarker.MatchString(strings.TrimSpace(line)) {
+			numbered = true
+			flush()
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	if numbered {
+		return tweets
+	}
+
+	tweets = nil
+	for _, paragraph := range strings.Split(strings.TrimSpace(content), "\n\n") {
+		if tweet := strings.TrimSpace(paragraph); tweet != "" {
+			tweets = append(tweets, tweet)
+		}
+	}
+	return tweets
+}
+
+// CheckContentLength returns a warning for each part of content that exceeds
+// its platform's limit: any tweet in a Twitter thread over TweetCharLimit, or a
+// LinkedIn post over LinkedInCharLimit
+func CheckContentLength(format, content string) []string {
+	var warnings []string
+	switch format {
+	case ContentFormatTwitterThread:
+		for i, tweet := range SplitTweets(content) {
+			if length := utf8.RuneCountInString(tweet); length > TweetCharLimit {
+				warnings = append(warnings, fmt.Sprintf("Tweet %d is %d characters, over the %d limit", i+1, length, TweetCharLimit))
+			}
+		}
+	case ContentFormatLinkedInPost:
+		if length := utf8.RuneCountInString(strings.TrimSpace(content)); length > LinkedInCharLimit {
+			warnings = append(warnings, fmt.Sprintf("The post is %d characters, over LinkedIn's %d limit", length, LinkedInCharLimit))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitTweets(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "Numbered",
+			content:  "🧵 1/3 Hook\n\nwith a second paragraph\n2/3 Context\n**3/3** Call to action",
+			expected: []string{"🧵 1/3 Hook\n\nwith a second paragraph", "2/3 Context", "**3/3** Call to action"},
+		},
+		{
+			name:     "Tweet prefix",
+			content:  "Tweet 1/N: Hook\nTweet 2/N: More",
+			expected: []string{"Tweet 1/N: Hook", "Tweet 2/N: More"},
+		},
+		{
+			name:     "Unnumbered",
+			content:  "First tweet\n\nSecond tweet\n\n\nThird tweet",
+			expected: []string{"First tweet", "Second tweet", "Third tweet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitTweets(tt.content)
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckContentLength(t *testing.T) {
+	long := strings.Repeat("a", TweetCharLimit)
+	thread := "1/3 Short\n2/3 " + long + "\n3/3 Short"
+	warnings := CheckContentLength(ContentFormatTwitterThread, thread)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Tweet 2") {
+		t.Errorf("Expected a warning for tweet 2, got %v", warnings)
+	}
+
+	// Limits count characters, not bytes
+	if warnings := CheckContentLength(ContentFormatTwitterThread, strings.Repeat("🚀", TweetCharLimit)); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for a tweet at the limit, got %v", warnings)
+	}
+
+	if warnings := CheckContentLength(ContentFormatLinkedInPost, strings.Repeat("a", LinkedInCharLimit+1)); len(warnings) != 1 {
+		t.Errorf("Expected a warning for a long LinkedIn post, got %v", warnings)
+	}
+
+	if warnings := CheckContentLength(ContentFormatBlogArticle, strings.Repeat("a", 50000)); len(warnings) != 0 {
+		t.Errorf("Expected blog articles to have no limit, got %v", warnings)
+	}
+}
@@ -0,0 +1,136 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// MockModeEnvVar selects the mock provider in place of the configured one,
+// see ParseMockMode for its values
+const MockModeEnvVar = "COMMITLORE_MOCK_MODE"
+
+// mockTopics is the default mock response, usable both as extracted topics
+// and as generated content
+var mockTopics = []string{
+	"Implementing modern Go patterns and best practices",
+	"Building terminal user interfaces with Bubble Tea",
+	"Git repository analysis and commit processing",
+	"Error handling and robust software design",
+	"API integration and external service communication",
+}
+
+// MockOptions configures how a MockProvider answers
+type MockOptions struct {
+	// Name is reported as the provider name, "Mock" if empty
+	Name string
+	// Response is returned for every request, a list of sample topics if empty
+	Response string
+	// Echo returns the prompts instead of Response
+	Echo bool
+	// Err is returned instead of a response
+	Err error
+	// Latency delays each answer. The request context still applies, so a
+	// latency past the caller's deadline simulates a timeout.
+	Latency time.Duration
+}
+
+// MockProvider answers requests without calling an LLM, so the generating,
+// error and timeout states can be exercised offline
+type MockProvider struct {
+	opts MockOptions
+}
+
+// NewMockProvider creates a mock provider with the given options
+func NewMockProvider(opts MockOptions) *MockProvider {
+	return &MockProvider{opts: opts}
+}
+
+// GenerateContent answers a prompt as configured
+func (p *MockProvider) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return p.GenerateContentWithSystemPrompt(ctx, "", prompt)
+}
+
+// GenerateContentWithSystemPrompt answers a request as configured, after the
+// configured latency
+func (p *MockProvider) GenerateContentWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	if p.opts.Latency > 0 {
+		timer := time.NewTimer(p.opts.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	if p.opts.Err != nil {
+		return "", p.opts.Err
+	}
+
+	response := p.opts.Response
+	switch {
+	case p.opts.Echo:
+		response = userPrompt
+		if systemPrompt != "" {
+			response = systemPrompt + "\n\n" + userPrompt
+		}
+	case response == "":
+		response = strings.Join(mockTopics, "\n") + "\n"
+	}
+
+	recordUsage(ctx, core.EstimateTokenCount(systemPrompt)+core.EstimateTokenCount(userPrompt), core.EstimateTokenCount(response))
+	return response, nil
+}
+
+// ModelInfo returns the configured name and the "mock" model
+func (p *MockProvider) ModelInfo() (string, string) {
+	if p.opts.Name == "" {
+		return "Mock", "mock"
+	}
+	return p.opts.Name, "mock"
+}
+
+// ParseMockMode parses the comma-separated COMMITLORE_MOCK_MODE value:
+//
+//	echo              answer with the prompts
+//	error[=message]   fail every request
+//	context           fail with ErrContextLengthExceeded
+//	timeout           never answer, so requests hit their deadline
+//	latency=duration  wait before answering, e.g. latency=3s
+//
+// Any other non-empty value, like "1", selects the mock with its defaults.
+func ParseMockMode(value string) (MockOptions, error) {
+	var opts MockOptions
+	for _, field := range strings.Split(value, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(field), "=")
+		switch strings.ToLower(name) {
+		case "", "1", "true", "on":
+		case "echo":
+			opts.Echo = true
+		case "error":
+			message := "mock provider error"
+			if hasArg && arg != "" {
+				message = arg
+			}
+			opts.Err = errors.New(message)
+		case "context":
+			opts.Err = ErrContextLengthExceeded
+		case "timeout":
+			opts.Latency = 24 * time.Hour
+		case "latency":
+			latency, err := time.ParseDuration(arg)
+			if err != nil || latency < 0 {
+				return opts, fmt.Errorf("invalid mock latency %q", arg)
+			}
+			opts.Latency = latency
+		default:
+			return opts, fmt.Errorf("unknown mock mode %q", field)
+		}
+	}
+	return opts, nil
+}
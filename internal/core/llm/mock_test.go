@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMockMode(t *testing.T) {
+	opts, err := ParseMockMode("echo, latency=2s")
+	if err != nil {
+		t.Fatalf("ParseMockMode failed: %v", err)
+	}
+	if !opts.Echo || opts.Latency != 2*time.Second {
+		t.Errorf("Expected echo with 2s latency, got %+v", opts)
+	}
+
+	opts, err = ParseMockMode("error=rate limited")
+	if err != nil || opts.Err == nil || opts.Err.Error() != "rate limited" {
+		t.Errorf("Expected a rate limited error, got %+v (%v)", opts, err)
+	}
+
+	opts, err = ParseMockMode("context")
+	if err != nil || !errors.Is(opts.Err, ErrContextLengthExceeded) {
+		t.Errorf("Expected a context length error, got %+v (%v)", opts, err)
+	}
+
+	if _, err := ParseMockMode("1"); err != nil {
+		t.Errorf("Expected 1 to select the default mock, got %v", err)
+	}
+	if _, err := ParseMockMode("latency=soon"); err == nil {
+		t.Error("Expected an error for an invalid latency")
+	}
+	if _, err := ParseMockMode("explode"); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+}
+
+func TestMockProvider(t *testing.T) {
+	ctx := context.Background()
+
+	response, err := NewMockProvider(MockOptions{}).GenerateContentWithSystemPrompt(ctx, "system", "user")
+	if err != nil || len(ParseTopicsFromResponse(response)) != len(mockTopics) {
+		t.Errorf("Expected the default topics, got %q (%v)", response, err)
+	}
+
+	response, err = NewMockProvider(MockOptions{Echo: true}).GenerateContentWithSystemPrompt(ctx, "system", "user")
+	if err != nil || response != "system\n\nuser" {
+		t.Errorf("Expected the prompts echoed back, got %q (%v)", response, err)
+	}
+
+	if _, err := NewMockProvider(MockOptions{Err: errors.New("boom")}).GenerateContent(ctx, "prompt"); err == nil || err.Error() != "boom" {
+		t.Errorf("Expected the configured error, got %v", err)
+	}
+
+	// Latency past the deadline ends with the context's error
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = NewMockProvider(MockOptions{Latency: time.Hour}).GenerateContent(timeoutCtx, "prompt")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Expected the mock to stop waiting at the deadline")
+	}
+
+	if provider, model := NewMockProvider(MockOptions{}).ModelInfo(); provider != "Mock" || !strings.Contains(model, "mock") {
+		t.Errorf("Unexpected model info %q %q", provider, model)
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
@@ -15,18 +15,82 @@ import (
 // Compile-time interface compliance check
 var _ LLMProvider = (*OpenAIClient)(nil)
 
-// NewOpenAIClient creates a new OpenAI API client
-func NewOpenAIClient(apiKey string) *OpenAIClient {
+// DefaultOpenAIBaseURL is the public OpenAI API endpoint
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// DefaultOpenAIModel is the model used when none is configured
+const DefaultOpenAIModel = "gpt-3.5-turbo"
+
+// OpenAIClientOptions customises how the OpenAI API client talks to the API.
+// Zero values fall back to the defaults.
+type OpenAIClientOptions struct {
+	// BaseURL points the client at a proxy, API gateway or any service with
+	// an OpenAI-compatible /chat/completions endpoint
+	BaseURL string
+	// Model is sent with each request, DefaultOpenAIModel if empty
+	Model string
+	// Name is reported as the provider name, "OpenAI API" if empty
+	Name string
+	// Headers are extra HTTP headers sent with every request
+	Headers map[string]string
+	// MaxTokens caps the length of a response
+	MaxTokens int
+	// Timeout caps each HTTP request. Zero leaves cancellation to the request
+	// context, whose deadline AsyncLLMWrapper sets.
+	Timeout time.Duration
+	// Temperature is the default sampling temperature, nil for the API's default
+	Temperature *float64
+}
+
+// NewOpenAIClient creates a new OpenAI API client for the given model. An
+// empty model uses DefaultOpenAIModel.
+func NewOpenAIClient(apiKey, model string) *OpenAIClient {
+	return NewOpenAIClientWithOptions(apiKey, OpenAIClientOptions{Model: model})
+}
+
+// NewOpenAIClientWithBaseURL creates a new OpenAI API client that talks to the
+// given base URL, e.g. a proxy or API gateway. An empty baseURL uses the public endpoint.
+func NewOpenAIClientWithBaseURL(apiKey, baseURL string) *OpenAIClient {
+	return NewOpenAIClientWithOptions(apiKey, OpenAIClientOptions{BaseURL: baseURL})
+}
+
+// NewOpenAIClientWithOptions creates a new OpenAI API client with the given options
+func NewOpenAIClientWithOptions(apiKey string, opts OpenAIClientOptions) *OpenAIClient {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "OpenAI API"
+	}
+
 	logger := core.GetLogger()
-	logger.Info("Creating new OpenAI API client", "provider", "openai-api", "model", "gpt-3.5-turbo")
+	logger.Info("Creating new OpenAI API client", "provider", name, "model", model, "base_url", baseURL, "max_tokens", maxTokens, "timeout", opts.Timeout)
 	
 	return &OpenAIClient{
 		apiKey: apiKey,
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout: opts.Timeout,
 		},
-		baseURL: "https://api.openai.com/v1",
-		model:   "gpt-3.5-turbo",
+		baseURL:     baseURL,
+		model:       model,
+		name:        name,
+		headers:     opts.Headers,
+		maxTokens:   maxTokens,
+		temperature: opts.Temperature,
 	}
 }
 
@@ -67,8 +131,8 @@ func (c *OpenAIClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	req := OpenAIRequest{
 		Model:       c.model,
 		Messages:    messages,
-		MaxTokens:   4000,
-		Temperature: 0.7,
+		MaxTokens:   c.maxTokens,
+		Temperature: requestTemperature(ctx, c.temperature),
 	}
 
 	reqBody, err := json.Marshal(req)
@@ -79,47 +143,46 @@ func (c *OpenAIClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	
 	logger.Debug("Marshaled request", "request_size", len(reqBody))
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(reqBody))
-	if err != nil {
-		logger.Error("Failed to create HTTP request", "provider", "openai-api", "error", err, "url", c.baseURL+"/chat/completions")
-		return "", fmt.Errorf("failed to create request: %w", err)
+	newRequest := func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		// Local servers such as LM Studio don't need a key
+		if c.apiKey != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		for name, value := range c.headers {
+			httpReq.Header.Set(name, value)
+		}
+		return httpReq, nil
 	}
-	
-	logger.Debug("Created HTTP request", "url", c.baseURL+"/chat/completions", "method", "POST")
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
 
-	logger.Debug("Making HTTP request to OpenAI API")
-	resp, err := c.httpClient.Do(httpReq)
+	logger.Debug("Making HTTP request to OpenAI API", "url", c.baseURL+"/chat/completions", "method", "POST")
+	statusCode, respBody, err := doWithRetry(ctx, c.httpClient, "openai-api", newRequest)
 	if err != nil {
 		logger.Error("Failed to make HTTP request to OpenAI API", "provider", "openai-api", "error", err, "duration", time.Since(start))
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 	
-	logger.Debug("Received HTTP response", "status_code", resp.StatusCode, "duration", time.Since(start))
+	logger.Debug("Received HTTP response", "status_code", statusCode, "response_size", len(respBody), "duration", time.Since(start))
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error("Failed to read response body", "provider", "openai-api", "error", err)
-		return "", fmt.Errorf("failed to read response: %w", err)
-	}
-	
-	logger.Debug("Read response body", "response_size", len(respBody))
-
-	if resp.StatusCode != http.StatusOK {
+	if statusCode != http.StatusOK {
 		logger.Error("OpenAI API request failed", 
 			"provider", "openai-api",
-			"status_code", resp.StatusCode, 
-			"response_body", string(respBody),
+			"status_code", statusCode, 
+			"response_body", core.LogExcerpt(string(respBody)),
 			"duration", time.Since(start))
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		if isContextLengthMessage(string(respBody)) {
+			return "", fmt.Errorf("%w: API request failed with status %d: %s", ErrContextLengthExceeded, statusCode, string(respBody))
+		}
+		return "", fmt.Errorf("API request failed with status %d: %s", statusCode, string(respBody))
 	}
 
 	var openaiResp OpenAIResponse
 	if err := json.Unmarshal(respBody, &openaiResp); err != nil {
-		logger.Error("Failed to unmarshal OpenAI API response", "provider", "openai-api", "error", err, "response_body", string(respBody))
+		logger.Error("Failed to unmarshal OpenAI API response", "provider", "openai-api", "error", err, "response_body", core.LogExcerpt(string(respBody)))
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 	
@@ -131,6 +194,7 @@ func (c *OpenAIClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 	}
 
 	responseText := openaiResp.Choices[0].Message.Content
+	recordUsage(ctx, openaiResp.Usage.PromptTokens, openaiResp.Usage.CompletionTokens)
 	logger.Info("Successfully generated content with OpenAI API", 
 		"provider", "openai-api",
 		"response_length", len(responseText),
@@ -141,4 +205,22 @@ func (c *OpenAIClient) GenerateContentWithSystemPrompt(ctx context.Context, syst
 		"total_tokens", openaiResp.Usage.TotalTokens)
 	
 	return responseText, nil
-}
\ No newline at end of file
+}
+
+// ModelInfo returns the provider name and the model used for requests
+func (c *OpenAIClient) ModelInfo() (string, string) {
+	return c.name, c.model
+}
+
+// MaxTokens returns the cap on the length of a response
+func (c *OpenAIClient) MaxTokens() int {
+	return c.maxTokens
+}
+
+// DefaultTemperature returns the temperature used when a request doesn't set one
+func (c *OpenAIClient) DefaultTemperature() float64 {
+	if c.temperature != nil {
+		return *c.temperature
+	}
+	return openAIDefaultTemperature
+}
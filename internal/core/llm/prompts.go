@@ -1,7 +1,12 @@
 package llm
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 )
@@ -36,7 +41,21 @@ For each significant finding, provide:
 4. Potential impact or learning value
 
 Input: Commit changelist with diffs, commit messages, and metadata
-Output: Structured analysis of technical achievements and learning moments in JSON format.`
+Output: Structured analysis of technical achievements and learning moments as a single JSON object, with no text before or after it, in this shape:
+
+{
+  "summary": "One or two sentences on the overall work",
+  "findings": [
+    {
+      "title": "Short name for the finding",
+      "description": "What happened",
+      "challenge": "The technical challenge or achievement",
+      "skills": ["Skill or technology", "..."],
+      "impact": "Potential impact or learning value",
+      "commits": ["abbreviated hashes of the commits involved"]
+    }
+  ]
+}`
 
 // ContentGenerationPrompt creates tailored content in multiple formats from commit analysis
 const ContentGenerationPrompt = `You are a skilled technical content creator specializing in developer-focused content. Using the provided commit analysis, generate engaging content in the specified format.
@@ -499,43 +518,101 @@ TARGET AUDIENCE CONSIDERATIONS:
 Input: Code changes, commit history, and technical context
 Output: Comprehensive technical documentation (5000-10000 words) with detailed implementation guides, API references, and operational procedures ready for publication in documentation systems.`
 
-// ContentCreationPromptTemplate creates a dynamic prompt for content generation
-func GetContentCreationPrompt(format, topic string) string {
-	logger := core.GetLogger()
-	logger.Debug("Creating content creation prompt", 
-		"format", format,
-		"topic", topic)
-	
-	var systemPrompt string
-	
+// SocialPreviewPrompt writes the social sharing metadata for a finished blog post
+const SocialPreviewPrompt = `You are an SEO and social media editor preparing a developer blog post for sharing. Given the post's topic and content, write:
+
+1. A social preview description (og:description) of 120-160 characters that makes developers want to click, without clickbait
+2. Alt text for a hero image illustrating the post, describing the image concisely for screen reader users in under 125 characters
+
+Respond with exactly two lines in this format and nothing else:
+description: <social preview description>
+alt: <hero image alt text>`
+
+// PromptTemplateDir is the directory under the data directory holding user
+// prompt templates, one <format>.tmpl file per content format
+const PromptTemplateDir = "templates"
+
+// PromptData is the data available to user prompt templates
+type PromptData struct {
+	Topic      string
+	Format     string
+	Changelist string
+	// Contributors lists the authors and co-authors of the commits when
+	// there is more than one, empty otherwise
+	Contributors string
+	// RepositoryURL is the web address of the repository's GitHub or GitLab
+	// origin, e.g. https://github.com/owner/repo, empty when there is none
+	RepositoryURL string
+}
+
+// DefaultContentPrompt returns the built-in system prompt for a content format
+func DefaultContentPrompt(format string) string {
 	switch format {
 	case ContentFormatTwitterThread:
-		systemPrompt = TwitterThreadPrompt
+		return TwitterThreadPrompt
 	case ContentFormatBlogArticle:
-		systemPrompt = BlogPostPrompt
+		return BlogPostPrompt
 	case ContentFormatLinkedInPost:
-		systemPrompt = LinkedInPostPrompt
+		return LinkedInPostPrompt
 	case ContentFormatTechnicalDocs:
-		systemPrompt = TechnicalDocumentationPrompt
+		return TechnicalDocumentationPrompt
 	default:
-		systemPrompt = ContentGenerationPrompt
+		return ContentGenerationPrompt
+	}
+}
+
+// PromptTemplateFileName returns the template file name for a content format,
+// e.g. "blog-article.tmpl" for "Blog Article"
+func PromptTemplateFileName(format string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(format)), " ", "-") + ".tmpl"
+}
+
+// GetContentCreationPrompt returns the system prompt for generating content in
+// the given format. A template in the templates directory of the data
+// directory (~/.commitlore/templates by default) overrides the built-in
+// prompt; if none exists or it fails to render, the built-in prompt is used.
+func GetContentCreationPrompt(format string, data PromptData) string {
+	logger := core.GetLogger()
+
+	dataDir, err := core.DataDir()
+	if err != nil {
+		logger.Warn("No data directory for prompt templates, using built-in prompt", "error", err)
+		return DefaultContentPrompt(format)
+	}
+
+	prompt, ok, err := renderPromptTemplate(filepath.Join(dataDir, PromptTemplateDir), format, data)
+	if err != nil {
+		logger.Warn("Failed to render prompt template, using built-in prompt", "format", format, "error", err)
+		return DefaultContentPrompt(format)
+	}
+	if !ok {
+		return DefaultContentPrompt(format)
+	}
+
+	logger.Debug("Using prompt template", "format", format, "prompt_length", len(prompt))
+	return prompt
+}
+
+// renderPromptTemplate executes the template for format in dir. It reports
+// false without an error when the format has no template.
+func renderPromptTemplate(dir, format string, data PromptData) (string, bool, error) {
+	path := filepath.Join(dir, PromptTemplateFileName(format))
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read prompt template %s: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(content))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse prompt template %s: %w", path, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", false, fmt.Errorf("failed to execute prompt template %s: %w", path, err)
 	}
-	
-	userPrompt := fmt.Sprintf(`Create %s content about: %s
-
-Please ensure the content is:
-- Technically accurate and up-to-date
-- Engaging and valuable to developers
-- Properly formatted for the target platform
-- Includes relevant code examples where applicable
-- Optimized for engagement and sharing
-
-Topic Context: %s`, format, topic, topic)
-	
-	logger.Debug("Generated content creation prompt", 
-		"format", format,
-		"topic", topic,
-		"prompt_length", len(systemPrompt) + len(userPrompt))
-	
-	return systemPrompt + "\n\n" + userPrompt
-}
\ No newline at end of file
+	return sb.String(), true, nil
+}
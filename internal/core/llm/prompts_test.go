@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPromptTemplateFileName(t *testing.T) {
+	tests := map[string]string{
+		ContentFormatBlogArticle:   "blog-article.tmpl",
+		ContentFormatTwitterThread: "twitter-thread.tmpl",
+		ContentFormatTechnicalDocs: "technical-documentation.tmpl",
+	}
+	for format, expected := range tests {
+		if got := PromptTemplateFileName(format); got != expected {
+			t.Errorf("PromptTemplateFileName(%q) = %q, expected %q", format, got, expected)
+		}
+	}
+}
+
+func TestDefaultContentPrompt(t *testing.T) {
+	tests := map[string]string{
+		ContentFormatBlogArticle:   BlogPostPrompt,
+		ContentFormatTwitterThread: TwitterThreadPrompt,
+		ContentFormatLinkedInPost:  LinkedInPostPrompt,
+		ContentFormatTechnicalDocs: TechnicalDocumentationPrompt,
+		"Newsletter":               ContentGenerationPrompt,
+	}
+	for format, expected := range tests {
+		if DefaultContentPrompt(format) != expected {
+			t.Errorf("DefaultContentPrompt(%q) returned the wrong prompt", format)
+		}
+	}
+}
+
+func TestGetContentCreationPromptTemplate(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("COMMITLORE_HOME", dataDir)
+	data := PromptData{Topic: "Faster diffs", Format: ContentFormatBlogArticle, Changelist: "abc123 Speed up diffing"}
+
+	if got := GetContentCreationPrompt(ContentFormatBlogArticle, data); got != BlogPostPrompt {
+		t.Errorf("Expected the built-in prompt without a template, got %q", got)
+	}
+
+	templateDir := filepath.Join(dataDir, PromptTemplateDir)
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("Failed to create template directory: %v", err)
+	}
+	templatePath := filepath.Join(templateDir, "blog-article.tmpl")
+	if err := os.WriteFile(templatePath, []byte("Write about {{.Topic}} using:\n{{.Changelist}}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	expected := "Write about Faster diffs using:\nabc123 Speed up diffing"
+	if got := GetContentCreationPrompt(ContentFormatBlogArticle, data); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// Other formats keep their built-in prompts
+	if got := GetContentCreationPrompt(ContentFormatLinkedInPost, data); got != LinkedInPostPrompt {
+		t.Errorf("Expected the built-in LinkedIn prompt, got %q", got)
+	}
+
+	// A broken template falls back to the built-in prompt
+	if err := os.WriteFile(templatePath, []byte("Write about {{.Missing}}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if got := GetContentCreationPrompt(ContentFormatBlogArticle, data); !strings.HasPrefix(got, "You are a professional technical writer") {
+		t.Errorf("Expected the built-in prompt for a broken template, got %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// maxRetries is how many times a request is retried after a transient error
+const maxRetries = 3
+
+// retryBaseDelay is the backoff before the first retry, doubling on each
+// subsequent attempt. It is a variable so tests can shorten it.
+var retryBaseDelay = time.Second
+
+// maxRetryDelay caps how long a Retry-After header can make us wait
+const maxRetryDelay = time.Minute
+
+// isRetryableStatus reports whether a response status is worth retrying:
+// rate limits, overloads and transient server errors
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		529: // Anthropic's overloaded_error
+		return true
+	}
+	return false
+}
+
+// doer sends HTTP requests, satisfied by *http.Client
+type doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// doWithRetry sends the request built by newRequest and reads the response,
+// retrying transient failures with exponential backoff. A Retry-After header
+// overrides the backoff. Retrying stops early if the wait would run past the
+// context deadline, in which case the last response is returned as is.
+func doWithRetry(ctx context.Context, client doer, provider string, newRequest func() (*http.Request, error)) (int, []byte, error) {
+	logger := core.GetLogger()
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt >= maxRetries {
+			return resp.StatusCode, body, nil
+		}
+
+		delay := retryDelay(resp.Header.Get("Retry-After"), attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			logger.Warn("Not retrying, backoff would exceed the deadline",
+				"provider", provider,
+				"status_code", resp.StatusCode,
+				"delay", delay)
+			return resp.StatusCode, body, nil
+		}
+
+		logger.Warn("Retrying after transient API error",
+			"provider", provider,
+			"status_code", resp.StatusCode,
+			"attempt", attempt+1,
+			"delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return 0, nil, fmt.Errorf("failed to make request: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying, using the Retry-After
+// header when it holds a number of seconds or a date, otherwise exponential
+// backoff from retryBaseDelay
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			delay = time.Until(date)
+		}
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
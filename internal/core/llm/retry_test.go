@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClaudeClientRetriesTransientErrors(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = time.Second }()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(529)
+			w.Write([]byte(`{"type":"error","error":{"type":"overloaded_error"}}`))
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"id":"msg_1","content":[{"type":"text","text":"hello"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL})
+	response, err := client.GenerateContent(context.Background(), "prompt")
+	if err != nil {
+		t.Fatalf("Expected retries to succeed, got error: %v", err)
+	}
+	if response != "hello" {
+		t.Errorf("Expected response 'hello', got %q", response)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+}
+
+func TestClaudeClientDoesNotRetryClientErrors(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = time.Second }()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("invalid x-api-key"))
+	}))
+	defer server.Close()
+
+	client := NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL})
+	_, err := client.GenerateContent(context.Background(), "prompt")
+	if err == nil || !strings.Contains(err.Error(), "API request failed with status 401") {
+		t.Fatalf("Expected a 401 error, got: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single request, got %d", got)
+	}
+}
+
+func TestOpenAIClientGivesUpAfterMaxRetries(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = time.Second }()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClientWithOptions("key", OpenAIClientOptions{BaseURL: server.URL})
+	_, err := client.GenerateContent(context.Background(), "prompt")
+	if err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Fatalf("Expected a 503 error, got: %v", err)
+	}
+	if got := requests.Load(); got != maxRetries+1 {
+		t.Errorf("Expected %d requests, got %d", maxRetries+1, got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if got := retryDelay("", 2); got != retryBaseDelay*4 {
+		t.Errorf("Expected exponential backoff of %v, got %v", retryBaseDelay*4, got)
+	}
+	if got := retryDelay("7", 0); got != 7*time.Second {
+		t.Errorf("Expected Retry-After of 7s, got %v", got)
+	}
+	if got := retryDelay("3600", 0); got != maxRetryDelay {
+		t.Errorf("Expected Retry-After to be capped at %v, got %v", maxRetryDelay, got)
+	}
+}
@@ -0,0 +1,58 @@
+package llm
+
+import (
+	"context"
+	"math"
+)
+
+// openAIDefaultTemperature is the temperature the OpenAI API uses when the
+// request doesn't set one
+const openAIDefaultTemperature = 1.0
+
+// claudeDefaultTemperature is the temperature the Claude API uses when the
+// request doesn't set one
+const claudeDefaultTemperature = 1.0
+
+// Temperature bounds accepted by every provider
+const (
+	MinTemperature = 0.0
+	MaxTemperature = 1.0
+)
+
+// TemperatureReporter is implemented by providers that sample with a
+// temperature, reporting the one used when a request doesn't override it
+type TemperatureReporter interface {
+	DefaultTemperature() float64
+}
+
+// temperatureKey is the context key under which a request's temperature is set
+type temperatureKey struct{}
+
+// WithTemperature returns a context that asks the provider to sample with the
+// given temperature, clamped to MinTemperature and MaxTemperature. Providers
+// without a temperature setting ignore it.
+func WithTemperature(ctx context.Context, temperature float64) context.Context {
+	return context.WithValue(ctx, temperatureKey{}, ClampTemperature(temperature))
+}
+
+// temperatureFromContext returns the temperature set with WithTemperature, if any
+func temperatureFromContext(ctx context.Context) (float64, bool) {
+	temperature, ok := ctx.Value(temperatureKey{}).(float64)
+	return temperature, ok
+}
+
+// ClampTemperature limits temperature to MinTemperature and MaxTemperature,
+// rounded to one decimal so repeated nudges don't drift
+func ClampTemperature(temperature float64) float64 {
+	temperature = math.Round(temperature*10) / 10
+	return math.Max(MinTemperature, math.Min(MaxTemperature, temperature))
+}
+
+// requestTemperature picks the temperature for a request: the one set on the
+// context, else the configured one, else nil to leave it to the API
+func requestTemperature(ctx context.Context, configured *float64) *float64 {
+	if temperature, ok := temperatureFromContext(ctx); ok {
+		return &temperature
+	}
+	return configured
+}
@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClaudeClientTemperature(t *testing.T) {
+	var request map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = nil
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"id":"msg_1","content":[{"type":"text","text":"hello"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL})
+	if _, err := client.GenerateContent(context.Background(), "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if _, ok := request["temperature"]; ok {
+		t.Errorf("Expected no temperature without a setting, got %v", request["temperature"])
+	}
+	if got := client.DefaultTemperature(); got != claudeDefaultTemperature {
+		t.Errorf("Expected default temperature %v, got %v", claudeDefaultTemperature, got)
+	}
+
+	ctx := WithTemperature(context.Background(), 0.2)
+	if _, err := client.GenerateContent(ctx, "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if request["temperature"] != 0.2 {
+		t.Errorf("Expected temperature 0.2 from the context, got %v", request["temperature"])
+	}
+
+	configured := 0.0
+	client = NewClaudeClientWithOptions("key", ClaudeClientOptions{BaseURL: server.URL, Temperature: &configured})
+	if _, err := client.GenerateContent(context.Background(), "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if request["temperature"] != 0.0 {
+		t.Errorf("Expected configured temperature 0, got %v", request["temperature"])
+	}
+}
+
+func TestOpenAIClientTemperature(t *testing.T) {
+	var request map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = nil
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"id":"1","choices":[{"message":{"role":"assistant","content":"hello"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClientWithOptions("key", OpenAIClientOptions{BaseURL: server.URL})
+	if _, err := client.GenerateContent(context.Background(), "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if _, ok := request["temperature"]; ok {
+		t.Errorf("Expected no temperature without a setting, got %v", request["temperature"])
+	}
+	if got := client.DefaultTemperature(); got != openAIDefaultTemperature {
+		t.Errorf("Expected default temperature %v, got %v", openAIDefaultTemperature, got)
+	}
+
+	configured := 0.3
+	client = NewOpenAIClientWithOptions("key", OpenAIClientOptions{BaseURL: server.URL, Temperature: &configured})
+	if _, err := client.GenerateContent(context.Background(), "prompt"); err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+	if request["temperature"] != 0.3 {
+		t.Errorf("Expected configured temperature 0.3, got %v", request["temperature"])
+	}
+}
+
+func TestClampTemperature(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected float64
+	}{
+		{0.5, 0.5},
+		{0.7 + 0.1, 0.8},
+		{-0.1, MinTemperature},
+		{1.3, MaxTemperature},
+	}
+
+	for _, test := range tests {
+		if got := ClampTemperature(test.value); got != test.expected {
+			t.Errorf("ClampTemperature(%v) = %v, expected %v", test.value, got, test.expected)
+		}
+	}
+}
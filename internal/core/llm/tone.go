@@ -0,0 +1,51 @@
+package llm
+
+import "fmt"
+
+// Tones are the audiences content can be written for, in the order they are
+// offered. The empty ToneDefault leaves the tone to the format's prompt.
+const (
+	ToneDefault      = ""
+	ToneBeginner     = "Beginner"
+	ToneIntermediate = "Intermediate"
+	ToneExpert       = "Expert"
+	ToneCasual       = "Casual"
+)
+
+// Tones lists the selectable tones, starting with ToneDefault
+var Tones = []string{ToneDefault, ToneBeginner, ToneIntermediate, ToneExpert, ToneCasual}
+
+// toneGuidance describes how each tone should shape the content
+var toneGuidance = map[string]string{
+	ToneBeginner:     "developers new to the topic: explain concepts and jargon, build up step by step, and keep code examples small",
+	ToneIntermediate: "working developers familiar with the basics: skip introductions and focus on practical trade-offs and patterns",
+	ToneExpert:       "experienced engineers: go deep on internals, edge cases and design decisions, without explaining fundamentals",
+	ToneCasual:       "a general developer audience in a relaxed, conversational voice, light on formality but still accurate",
+}
+
+// ToneInstruction returns the prompt instruction for writing in tone, or an
+// empty string for ToneDefault and unknown tones
+func ToneInstruction(tone string) string {
+	guidance, ok := toneGuidance[tone]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Written for %s", guidance)
+}
+
+// NextTone returns the tone after tone in Tones, wrapping around. A negative
+// step moves backwards.
+func NextTone(tone string, step int) string {
+	current := 0
+	for i, t := range Tones {
+		if t == tone {
+			current = i
+			break
+		}
+	}
+	next := (current + step) % len(Tones)
+	if next < 0 {
+		next += len(Tones)
+	}
+	return Tones[next]
+}
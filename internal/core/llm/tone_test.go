@@ -0,0 +1,32 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToneInstruction(t *testing.T) {
+	if instruction := ToneInstruction(ToneDefault); instruction != "" {
+		t.Errorf("Expected no instruction for the default tone, got %q", instruction)
+	}
+	if instruction := ToneInstruction("Pirate"); instruction != "" {
+		t.Errorf("Expected no instruction for an unknown tone, got %q", instruction)
+	}
+	for _, tone := range Tones[1:] {
+		if !strings.HasPrefix(ToneInstruction(tone), "Written for ") {
+			t.Errorf("Expected an instruction for %q, got %q", tone, ToneInstruction(tone))
+		}
+	}
+}
+
+func TestNextTone(t *testing.T) {
+	if tone := NextTone(ToneDefault, 1); tone != ToneBeginner {
+		t.Errorf("Expected Beginner after the default, got %q", tone)
+	}
+	if tone := NextTone(ToneCasual, 1); tone != ToneDefault {
+		t.Errorf("Expected the default after Casual, got %q", tone)
+	}
+	if tone := NextTone(ToneDefault, -1); tone != ToneCasual {
+		t.Errorf("Expected Casual before the default, got %q", tone)
+	}
+}
@@ -17,6 +17,8 @@ type ClaudeRequest struct {
 	MaxTokens int             `json:"max_tokens"`
 	Messages  []ClaudeMessage `json:"messages"`
 	System    string          `json:"system,omitempty"`
+	// Temperature is left out to use the API's default
+	Temperature *float64 `json:"temperature,omitempty"`
 }
 
 // ClaudeContent represents the content structure in Claude responses
@@ -38,12 +40,27 @@ type ClaudeResponse struct {
 	} `json:"usage"`
 }
 
+// ClaudeCountTokensRequest is the payload for the Claude API's count_tokens endpoint
+type ClaudeCountTokensRequest struct {
+	Model    string          `json:"model"`
+	Messages []ClaudeMessage `json:"messages"`
+}
+
+// ClaudeCountTokensResponse is the response from the count_tokens endpoint
+type ClaudeCountTokensResponse struct {
+	InputTokens int `json:"input_tokens"`
+}
+
 // ClaudeClient represents the Claude API client
 type ClaudeClient struct {
 	apiKey     string
 	httpClient interface{ Do(req *http.Request) (*http.Response, error) }
-	baseURL    string
-	model      string
+	baseURL     string
+	model       string
+	apiVersion  string
+	headers     map[string]string
+	maxTokens   int
+	temperature *float64 // nil uses the API's default
 }
 
 // ClaudeCLIClient represents the Claude CLI client
@@ -62,7 +79,7 @@ type OpenAIRequest struct {
 	Model       string          `json:"model"`
 	Messages    []OpenAIMessage `json:"messages"`
 	MaxTokens   int             `json:"max_tokens,omitempty"`
-	Temperature float32         `json:"temperature,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
 }
 
 // OpenAIChoice represents a choice in the OpenAI response
@@ -90,10 +107,14 @@ type OpenAIResponse struct {
 
 // OpenAIClient represents the OpenAI API client
 type OpenAIClient struct {
-	apiKey     string
-	httpClient interface{ Do(req *http.Request) (*http.Response, error) }
-	baseURL    string
-	model      string
+	apiKey      string
+	httpClient  interface{ Do(req *http.Request) (*http.Response, error) }
+	baseURL     string
+	model       string
+	name        string
+	headers     map[string]string
+	maxTokens   int
+	temperature *float64 // nil uses the API's default
 }
 
 // Changeset represents a git changeset for analysis
@@ -105,4 +126,5 @@ type Changeset struct {
 	Body       string
 	Files      []string
 	Diff       string
+	IsMerge    bool
 }
\ No newline at end of file
@@ -0,0 +1,79 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+// Usage is the token usage an API reported for a request
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// usageKey is the context key under which a request's Usage is recorded
+type usageKey struct{}
+
+// withUsage returns a context that collects the token usage reported by the
+// provider into usage. Providers that don't report usage leave it zero.
+func withUsage(ctx context.Context, usage *Usage) context.Context {
+	return context.WithValue(ctx, usageKey{}, usage)
+}
+
+// recordUsage stores the token usage of a request in the context, if it is
+// collecting usage
+func recordUsage(ctx context.Context, inputTokens, outputTokens int) {
+	if usage, ok := ctx.Value(usageKey{}).(*Usage); ok {
+		usage.InputTokens = inputTokens
+		usage.OutputTokens = outputTokens
+	}
+}
+
+// ModelPrice is the cost of a model in US dollars per million tokens
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// modelPrices lists rough list prices by model name prefix, so dated model
+// versions match their family
+var modelPrices = map[string]ModelPrice{
+	"claude-3-5-sonnet": {Input: 3, Output: 15},
+	"claude-3-7-sonnet": {Input: 3, Output: 15},
+	"claude-sonnet-4":   {Input: 3, Output: 15},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4},
+	"claude-3-haiku":    {Input: 0.25, Output: 1.25},
+	"claude-3-opus":     {Input: 15, Output: 75},
+	"claude-opus-4":     {Input: 15, Output: 75},
+	"gpt-3.5-turbo":     {Input: 0.5, Output: 1.5},
+	"gpt-4o":            {Input: 2.5, Output: 10},
+	"gpt-4o-mini":       {Input: 0.15, Output: 0.6},
+	"gpt-4-turbo":       {Input: 10, Output: 30},
+	"gpt-4":             {Input: 30, Output: 60},
+}
+
+// EstimateCost returns the approximate cost in US dollars of the given usage
+// with a model, matching the longest known model name prefix. It returns
+// false when the model's price is unknown.
+func EstimateCost(model string, usage Usage) (float64, bool) {
+	price, ok := longestPrefixMatch(modelPrices, model)
+	if !ok {
+		return 0, false
+	}
+
+	cost := float64(usage.InputTokens)*price.Input/1e6 + float64(usage.OutputTokens)*price.Output/1e6
+	return cost, true
+}
+
+// longestPrefixMatch returns the value for the longest key of values that
+// name starts with, so dated model versions match their family
+func longestPrefixMatch[V any](values map[string]V, name string) (V, bool) {
+	var best string
+	var found bool
+	for prefix := range values {
+		if strings.HasPrefix(name, prefix) && (!found || len(prefix) > len(best)) {
+			best, found = prefix, true
+		}
+	}
+	return values[best], found
+}
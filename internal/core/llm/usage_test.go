@@ -0,0 +1,25 @@
+package llm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	usage := Usage{InputTokens: 1_000_000, OutputTokens: 100_000}
+
+	cost, ok := EstimateCost("claude-3-5-sonnet-20241022", usage)
+	if !ok || math.Abs(cost-4.5) > 1e-9 {
+		t.Errorf("Expected claude-3-5-sonnet to cost $4.50, got %v (known: %v)", cost, ok)
+	}
+
+	// gpt-4o-mini must not be priced as gpt-4o or gpt-4
+	cost, ok = EstimateCost("gpt-4o-mini-2024-07-18", usage)
+	if !ok || math.Abs(cost-0.21) > 1e-9 {
+		t.Errorf("Expected gpt-4o-mini to cost $0.21, got %v (known: %v)", cost, ok)
+	}
+
+	if _, ok := EstimateCost("llama2", usage); ok {
+		t.Error("Expected unknown model to have no price")
+	}
+}
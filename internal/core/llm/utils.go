@@ -3,7 +3,9 @@ package llm
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	
 	"github.com/sarkarshuvojit/commitlore/internal/core"
@@ -39,7 +41,7 @@ Guidelines:
 	}
 	
 	// Parse the response to extract individual topics
-	topics := parseTopicsFromResponse(response)
+	topics := ParseTopicsFromResponse(response)
 	
 	return topics, nil
 }
@@ -54,6 +56,9 @@ func buildChangesetString(changesets []Changeset) string {
 		buffer.WriteString(fmt.Sprintf("Author: %s\n", changeset.Author))
 		buffer.WriteString(fmt.Sprintf("Date: %s\n", changeset.Date.Format("2006-01-02 15:04:05")))
 		buffer.WriteString(fmt.Sprintf("Subject: %s\n", changeset.Subject))
+		if changeset.IsMerge {
+			buffer.WriteString("Merge: yes, the diff shows what was merged into the first parent\n")
+		}
 		
 		if changeset.Body != "" {
 			buffer.WriteString(fmt.Sprintf("Body: %s\n", changeset.Body))
@@ -63,9 +68,13 @@ func buildChangesetString(changesets []Changeset) string {
 		
 		if changeset.Diff != "" {
 			// Truncate diff if too long to keep within token limits
-			diff := changeset.Diff
-			if len(diff) > 2000 {
-				diff = diff[:2000] + "\n... (truncated)"
+			diff, truncated := core.TruncateDiff(changeset.Diff, core.MaxDiffTokens())
+			if truncated {
+				core.GetLogger().Info("Truncated diff to fit the token budget",
+					"hash", changeset.CommitHash,
+					"original_tokens", core.EstimateTokenCount(changeset.Diff),
+					"max_tokens", core.MaxDiffTokens())
+				diff += "... (truncated)"
 			}
 			buffer.WriteString(fmt.Sprintf("Diff:\n%s\n", diff))
 		}
@@ -76,9 +85,15 @@ func buildChangesetString(changesets []Changeset) string {
 	return buffer.String()
 }
 
-// parseTopicsFromResponse extracts individual topics from the LLM response
-func parseTopicsFromResponse(response string) []string {
-	rawLines := strings.Split(response, "\n")
+// topicListMarker matches list numbering or bullets in front of a topic, like
+// "1.", "2)", "-", "*" or "•"
+var topicListMarker = regexp.MustCompile(`^(?:\d+[.)]|[-*•])\s+`)
+
+// ParseTopicsFromResponse extracts individual topics from the LLM response.
+// Topics are one per line, optionally numbered or bulleted. Commas are kept,
+// since a topic can contain one.
+func ParseTopicsFromResponse(response string) []string {
+	rawLines := strings.Split(strings.TrimSpace(response), "\n")
 	
 	var topics []string
 	var skippedLines int
@@ -126,9 +141,9 @@ func parseTopicsFromResponse(response string) []string {
 			continue
 		}
 		
-		// Remove common prefixes like numbers, bullets, dashes
-		line = strings.TrimLeft(line, "0123456789.-• ")
-		line = strings.TrimSpace(line)
+		// Remove common prefixes like numbers, bullets, dashes, and markdown emphasis
+		line = topicListMarker.ReplaceAllString(line, "")
+		line = strings.Trim(line, "*_` ")
 		
 		// Additional cleanup: remove colons at the end
 		line = strings.TrimRight(line, ":")
@@ -149,4 +164,89 @@ func parseTopicsFromResponse(response string) []string {
 		"skipped_introductory_lines", introductoryLines)
 	
 	return topics
-}
\ No newline at end of file
+}
+
+// SocialPreview holds the social sharing metadata for a piece of content
+type SocialPreview struct {
+	Description string
+	AltText     string
+}
+
+// ParseSocialPreview reads the description and alt text lines of a response
+// to SocialPreviewPrompt
+func ParseSocialPreview(response string) (SocialPreview, error) {
+	var preview SocialPreview
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.Trim(strings.TrimSpace(line), "*- ")
+		lower := strings.ToLower(line)
+		for _, prefix := range []string{"og:description:", "description:"} {
+			if strings.HasPrefix(lower, prefix) {
+				preview.Description = strings.Trim(line[len(prefix):], `*" `)
+				break
+			}
+		}
+		for _, prefix := range []string{"alt text:", "alt:"} {
+			if strings.HasPrefix(lower, prefix) {
+				preview.AltText = strings.Trim(line[len(prefix):], `*" `)
+				break
+			}
+		}
+	}
+
+	if preview.Description == "" || preview.AltText == "" {
+		return preview, fmt.Errorf("response is missing the description or alt text")
+	}
+	return preview, nil
+}
+
+// CommitAnalysis is the structured analysis of a set of commits returned for
+// CommitAnalysisPrompt
+type CommitAnalysis struct {
+	Summary  string            `json:"summary"`
+	Findings []AnalysisFinding `json:"findings"`
+}
+
+// AnalysisFinding is one technical achievement or learning moment in a
+// CommitAnalysis
+type AnalysisFinding struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Challenge   string   `json:"challenge"`
+	Skills      []string `json:"skills"`
+	Impact      string   `json:"impact"`
+	Commits     []string `json:"commits"`
+}
+
+// ParseCommitAnalysis reads the JSON object of a response to
+// CommitAnalysisPrompt. Text or a code fence around the object is ignored,
+// and a bare array of findings is accepted too.
+func ParseCommitAnalysis(response string) (*CommitAnalysis, error) {
+	start := strings.IndexAny(response, "{[")
+	if start < 0 {
+		return nil, fmt.Errorf("response contains no JSON")
+	}
+
+	var analysis CommitAnalysis
+	if response[start] == '[' {
+		end := strings.LastIndex(response, "]")
+		if end < start {
+			return nil, fmt.Errorf("response contains no complete JSON array")
+		}
+		if err := json.Unmarshal([]byte(response[start:end+1]), &analysis.Findings); err != nil {
+			return nil, fmt.Errorf("failed to parse analysis: %w", err)
+		}
+	} else {
+		end := strings.LastIndex(response, "}")
+		if end < start {
+			return nil, fmt.Errorf("response contains no complete JSON object")
+		}
+		if err := json.Unmarshal([]byte(response[start:end+1]), &analysis); err != nil {
+			return nil, fmt.Errorf("failed to parse analysis: %w", err)
+		}
+	}
+
+	if len(analysis.Findings) == 0 {
+		return nil, fmt.Errorf("analysis has no findings")
+	}
+	return &analysis, nil
+}
@@ -0,0 +1,178 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTopicsFromResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "Numbered",
+			response: "1. Implementing retry with backoff\n2) Designing a plugin registry\n10. Streaming large git diffs",
+			expected: []string{"Implementing retry with backoff", "Designing a plugin registry", "Streaming large git diffs"},
+		},
+		{
+			name:     "Bulleted",
+			response: "- Implementing retry with backoff\n* Designing a plugin registry\n• Streaming large git diffs",
+			expected: []string{"Implementing retry with backoff", "Designing a plugin registry", "Streaming large git diffs"},
+		},
+		{
+			name:     "Preamble",
+			response: "Here are the topics:\n\nImplementing retry with backoff\nDesigning a plugin registry",
+			expected: []string{"Implementing retry with backoff", "Designing a plugin registry"},
+		},
+		{
+			name:     "Short lines",
+			response: "Go\nShort one\n- Tests\nImplementing retry with backoff",
+			expected: []string{"Implementing retry with backoff"},
+		},
+		{
+			name:     "Trailing colons",
+			response: "Implementing retry with backoff:\n2. Designing a plugin registry::",
+			expected: []string{"Implementing retry with backoff", "Designing a plugin registry"},
+		},
+		{
+			name:     "Markdown emphasis",
+			response: "1. **Implementing retry with backoff**\n- `Designing a plugin registry`",
+			expected: []string{"Implementing retry with backoff", "Designing a plugin registry"},
+		},
+		{
+			name:     "Commas inside topics",
+			response: "Retries, backoff, and jitter in the uploader\n- Caching, invalidation and the plugin registry",
+			expected: []string{"Retries, backoff, and jitter in the uploader", "Caching, invalidation and the plugin registry"},
+		},
+		{
+			name:     "Single topic with a comma",
+			response: "Streaming large git diffs, one hunk at a time",
+			expected: []string{"Streaming large git diffs, one hunk at a time"},
+		},
+		{
+			name:     "Empty",
+			response: "  \n\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTopicsFromResponse(tt.response)
+			if len(got) != len(tt.expected) || strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseCommitAnalysis(t *testing.T) {
+	object := `{"summary": "Retries", "findings": [{"title": "Backoff", "skills": ["Go", "HTTP"], "commits": ["a1b2c3d"]}]}`
+
+	tests := []struct {
+		name     string
+		response string
+		findings int
+		wantErr  bool
+	}{
+		{name: "Object", response: object, findings: 1},
+		{name: "Fenced with preamble", response: "Here is the analysis:\n```json\n" + object + "\n```", findings: 1},
+		{name: "Bare array", response: `[{"title": "Backoff"}, {"title": "Jitter"}]`, findings: 2},
+		{name: "No JSON", response: "I could not analyze these commits.", wantErr: true},
+		{name: "Truncated", response: `{"summary": "Retries", "findings": [`, wantErr: true},
+		{name: "No findings", response: `{"summary": "Nothing notable", "findings": []}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := ParseCommitAnalysis(tt.response)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", analysis)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCommitAnalysis failed: %v", err)
+			}
+			if len(analysis.Findings) != tt.findings {
+				t.Errorf("Expected %d findings, got %d", tt.findings, len(analysis.Findings))
+			}
+		})
+	}
+
+	analysis, _ := ParseCommitAnalysis(object)
+	finding := analysis.Findings[0]
+	if analysis.Summary != "Retries" || finding.Title != "Backoff" || strings.Join(finding.Skills, ",") != "Go,HTTP" || finding.Commits[0] != "a1b2c3d" {
+		t.Errorf("Unexpected analysis %+v", analysis)
+	}
+}
+
+func TestParseSocialPreview(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		description string
+		altText     string
+		wantErr     bool
+	}{
+		{
+			name:        "Plain",
+			response:    "description: Why our uploads stopped failing\nalt: A chart of upload retries",
+			description: "Why our uploads stopped failing",
+			altText:     "A chart of upload retries",
+		},
+		{
+			name:        "Markdown and quotes",
+			response:    "Here you go:\n\n- **og:description:** \"Why our uploads stopped failing\"\n- **Alt text:** \"A chart of upload retries\"",
+			description: "Why our uploads stopped failing",
+			altText:     "A chart of upload retries",
+		},
+		{
+			name:        "Colons in the values",
+			response:    "Description: Retries: a story\nALT: Diagram: client to server",
+			description: "Retries: a story",
+			altText:     "Diagram: client to server",
+		},
+		{
+			name:     "Missing both fields",
+			response: "I could not write a preview for this post.",
+			wantErr:  true,
+		},
+		{
+			name:     "Missing alt text",
+			response: "description: Why our uploads stopped failing",
+			wantErr:  true,
+		},
+		{
+			name:     "Missing description",
+			response: "alt: A chart of upload retries",
+			wantErr:  true,
+		},
+		{
+			name:     "Empty value",
+			response: "description: \"\"\nalt: A chart of upload retries",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := ParseSocialPreview(tt.response)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", preview)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSocialPreview failed: %v", err)
+			}
+			if preview.Description != tt.description || preview.AltText != tt.altText {
+				t.Errorf("Expected %q and %q, got %+v", tt.description, tt.altText, preview)
+			}
+		})
+	}
+}
@@ -1,38 +1,219 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf8"
 )
 
 var logger *slog.Logger
+var logFilePath string
 
+// logFiles are the files the logger writes to, synced by SyncLogger
+var logFiles []*os.File
+
+// logContent is false when COMMITLORE_LOG_CONTENT turns off content logging
+var logContent = true
+
+// LogLevelEnvVar sets the minimum level written to the log, one of debug,
+// info, warn or error. Info is used when it is unset.
+const LogLevelEnvVar = "COMMITLORE_LOG_LEVEL"
+
+// LogFileEnvVar names an extra file the log is also written to, such as a
+// named pipe or /dev/stderr in another terminal, so logs can be followed
+// while the UI owns the terminal
+const LogFileEnvVar = "COMMITLORE_LOG_FILE"
+
+// LogContentEnvVar, when set to false, keeps prompts, responses and API error
+// bodies out of the log, for working on private code. Their sizes are still
+// logged.
+const LogContentEnvVar = "COMMITLORE_LOG_CONTENT"
+
+// maxExcerptLength is how much of an API error body or an unexpected
+// response LogExcerpt keeps
+const maxExcerptLength = 500
+
+// secretPattern matches values that look like API keys or bearer tokens
+var secretPattern = regexp.MustCompile(`(?i)\b(sk-[a-z0-9_-]{8,}|bearer\s+[a-z0-9._~+/-]{8,}=*)`)
+
+// InitLogger sets up logging to commitlore.log in the data directory, at the
+// level set by COMMITLORE_LOG_LEVEL, and to COMMITLORE_LOG_FILE when set. When
+// no writable directory can be found it logs to COMMITLORE_LOG_FILE alone, or
+// falls back to logging warnings and errors to stderr rather than failing, so
+// CommitLore still runs without a home directory.
 func InitLogger() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+	level, levelErr := ParseLogLevel(os.Getenv(LogLevelEnvVar))
+	logContent = true
+	var contentErr error
+	if value, ok := os.LookupEnv(LogContentEnvVar); ok && value != "" {
+		if logContent, contentErr = strconv.ParseBool(value); contentErr != nil {
+			logContent = true
+		}
+	}
+	extra, extraErr := openExtraLogFile(os.Getenv(LogFileEnvVar))
+	logFiles = nil
+	if extra != nil {
+		logFiles = append(logFiles, extra)
 	}
 
-	logDir := filepath.Join(homeDir, ".commitlore")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("failed to create log directory: %w", err)
+	var out io.Writer
+	logDir, err := DataDir()
+	if err == nil {
+		logFile := filepath.Join(logDir, "commitlore.log")
+		var file *os.File
+		if info, statErr := os.Stat(logFile); statErr == nil && !info.Mode().IsRegular() {
+			// Opening a named pipe would wait for a reader and hang startup
+			err = fmt.Errorf("log file %s is not a regular file", logFile)
+		} else if file, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+			err = fmt.Errorf("failed to open log file: %w", err)
+		} else {
+			out = file
+			logFilePath = logFile
+			logFiles = append(logFiles, file)
+		}
 	}
 
-	logFile := filepath.Join(logDir, "commitlore.log")
-	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open log file: %w", err)
+	switch {
+	case out != nil && extra != nil:
+		// The log file comes first so a closed pipe doesn't stop it being written
+		out = io.MultiWriter(out, extra)
+	case extra != nil:
+		out = extra
+		logFilePath = ""
+	case out == nil:
+		initStderrLogger(level, err)
+		if extraErr != nil {
+			logger.Warn("Not logging to "+LogFileEnvVar, "error", extraErr)
+		}
+		return nil
 	}
 
-	logger = slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+	logger = slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{
+		Level: level,
 	}))
 
+	if levelErr != nil {
+		logger.Warn("Ignoring invalid log level", "error", levelErr)
+	}
+	if contentErr != nil {
+		logger.Warn("Ignoring invalid "+LogContentEnvVar+", logging content", "error", contentErr)
+	}
+	if extraErr != nil {
+		logger.Warn("Not logging to "+LogFileEnvVar, "error", extraErr)
+	}
+	if err != nil {
+		logger.Warn("Logging only to "+LogFileEnvVar+", no writable log directory", "error", err)
+	}
+
 	return nil
 }
 
+// openExtraLogFile opens the COMMITLORE_LOG_FILE destination for appending,
+// or returns nil when path is empty. It can be a regular file, a named pipe or
+// a terminal. A named pipe is opened without waiting for a reader, so one
+// without a reader is an error rather than a hang at startup.
+func openExtraLogFile(path string) (*os.File, error) {
+	if path == "" {
+		return nil, nil
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if info, err := os.Stat(path); err == nil {
+		switch mode := info.Mode(); {
+		case mode&os.ModeNamedPipe != 0:
+			flags |= syscall.O_NONBLOCK
+		case !mode.IsRegular() && mode&os.ModeCharDevice == 0:
+			return nil, fmt.Errorf("%s is not a file, named pipe or terminal", path)
+		}
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// ParseLogLevel parses a COMMITLORE_LOG_LEVEL value. An empty value is
+// slog.LevelInfo; an unknown one is also slog.LevelInfo, with an error.
+func ParseLogLevel(value string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", value)
+	}
+}
+
+// initStderrLogger logs to stderr when no log file can be written. Only
+// warnings and errors are kept, whatever the configured level, to avoid
+// drawing over the terminal UI.
+func initStderrLogger(level slog.Level, reason error) {
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: max(level, slog.LevelWarn),
+	}))
+	logFilePath = ""
+	logger.Warn("Logging to stderr, no writable log directory", "error", reason)
+}
+
+// LogContent prepares a prompt or response for the log, masking anything
+// that looks like an API key. With COMMITLORE_LOG_CONTENT=false only its size
+// is logged.
+func LogContent(text string) string {
+	if !logContent {
+		return fmt.Sprintf("[%d bytes, not logged]", len(text))
+	}
+	return secretPattern.ReplaceAllString(text, "[redacted]")
+}
+
+// LogExcerpt is LogContent for text that is only logged to diagnose a
+// failure, like an API error body, which may echo the prompt. Only its start
+// is kept.
+func LogExcerpt(text string) string {
+	text = LogContent(text)
+	if !logContent || len(text) <= maxExcerptLength {
+		return text
+	}
+	cut := maxExcerptLength
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… [%d more bytes]", text[:cut], len(text)-cut)
+}
+
+// SyncLogger flushes the log files to disk, so the last lines survive the
+// process being stopped by a signal. Files that can't be synced, like pipes
+// and terminals, are skipped.
+func SyncLogger() error {
+	var errs []error
+	for _, file := range logFiles {
+		if err := file.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTSUP) {
+			errs = append(errs, fmt.Errorf("failed to sync %s: %w", file.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetLogFilePath returns the path of the log file written by the logger, or
+// an empty string when logging to stderr
+func GetLogFilePath() string {
+	return logFilePath
+}
+
 func GetLogger() *slog.Logger {
 	if logger == nil {
 		panic("logger not initialized - call InitLogger() first")
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected slog.Level
+		wantErr  bool
+	}{
+		{"", slog.LevelInfo, false},
+		{"debug", slog.LevelDebug, false},
+		{" DEBUG ", slog.LevelDebug, false},
+		{"info", slog.LevelInfo, false},
+		{"warn", slog.LevelWarn, false},
+		{"warning", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"verbose", slog.LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		level, err := ParseLogLevel(tt.value)
+		if level != tt.expected || (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogLevel(%q) = %v, %v; expected %v, error %v", tt.value, level, err, tt.expected, tt.wantErr)
+		}
+	}
+}
+
+// preserveLogger restores the package logger after a test that calls
+// InitLogger, closing the files the test's logger opened
+func preserveLogger(t *testing.T) {
+	t.Helper()
+	savedLogger, savedPath, savedFiles, savedContent := logger, logFilePath, logFiles, logContent
+	t.Cleanup(func() {
+		for _, file := range logFiles {
+			file.Close()
+		}
+		logger, logFilePath, logFiles, logContent = savedLogger, savedPath, savedFiles, savedContent
+	})
+}
+
+// mkfifo creates a named pipe, skipping the test where mkfifo isn't available
+func mkfifo(t *testing.T, path string) {
+	t.Helper()
+	if err := exec.Command("mkfifo", path).Run(); err != nil {
+		t.Skipf("Can't create a named pipe: %v", err)
+	}
+}
+
+func TestInitLoggerWithLogFile(t *testing.T) {
+	preserveLogger(t)
+	dir := t.TempDir()
+	extraPath := filepath.Join(dir, "extra.log")
+	t.Setenv("COMMITLORE_HOME", filepath.Join(dir, "home"))
+	t.Setenv(LogFileEnvVar, extraPath)
+	t.Setenv(LogLevelEnvVar, "debug")
+
+	if err := InitLogger(); err != nil {
+		t.Fatalf("InitLogger failed: %v", err)
+	}
+	GetLogger().Debug("mirrored message")
+	if err := SyncLogger(); err != nil {
+		t.Errorf("SyncLogger failed: %v", err)
+	}
+
+	for _, path := range []string{GetLogFilePath(), extraPath} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if !strings.Contains(string(data), "mirrored message") {
+			t.Errorf("Expected the debug message in %s, got %q", path, data)
+		}
+	}
+}
+
+func TestInitLoggerWithNamedPipes(t *testing.T) {
+	preserveLogger(t)
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home")
+	if err := os.MkdirAll(home, 0755); err != nil {
+		t.Fatalf("Failed to create home: %v", err)
+	}
+	mkfifo(t, filepath.Join(home, "commitlore.log"))
+	pipe := filepath.Join(dir, "extra.pipe")
+	mkfifo(t, pipe)
+	t.Setenv("COMMITLORE_HOME", home)
+	t.Setenv(LogFileEnvVar, pipe)
+
+	// Neither pipe has a reader, so opening them mustn't wait for one
+	done := make(chan error, 1)
+	go func() { done <- InitLogger() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("InitLogger failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("InitLogger hung opening a named pipe")
+	}
+	if GetLogFilePath() != "" {
+		t.Errorf("Expected no log file for a named pipe, got %s", GetLogFilePath())
+	}
+
+	if _, err := openExtraLogFile(dir); err == nil {
+		t.Error("Expected a directory to be rejected as a log file")
+	}
+}
+
+func TestLogContent(t *testing.T) {
+	logContent = true
+	t.Cleanup(func() { logContent = true })
+
+	if got := LogContent("key sk-ant-api03-abcdefgh and Bearer abc.def.ghi"); strings.Contains(got, "abcdefgh") || strings.Contains(got, "abc.def") {
+		t.Errorf("Expected the secrets to be masked, got %q", got)
+	}
+
+	excerpt := LogExcerpt(strings.Repeat("é", maxExcerptLength))
+	if !strings.HasSuffix(excerpt, "more bytes]") || !utf8.ValidString(excerpt) {
+		t.Errorf("Expected a valid truncated excerpt, got %q", excerpt)
+	}
+	if got := LogExcerpt("short body"); got != "short body" {
+		t.Errorf("Expected short text unchanged, got %q", got)
+	}
+
+	logContent = false
+	if got := LogExcerpt("private diff"); got != "[12 bytes, not logged]" {
+		t.Errorf("Expected only the size to be logged, got %q", got)
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// VagueMessageThreshold is the score below which a commit message is
+// considered too vague to write about on its own
+const VagueMessageThreshold = 40
+
+// vagueSubjects are commit subjects that say nothing about the change
+var vagueSubjects = map[string]bool{
+	"fix": true, "fixes": true, "fixed": true, "bugfix": true,
+	"wip": true, "update": true, "updates": true, "updated": true,
+	"change": true, "changes": true, "misc": true, "stuff": true,
+	"tmp": true, "temp": true, "test": true, "tests": true,
+	"minor": true, "cleanup": true, "refactor": true, "typo": true,
+	"more": true, "save": true, "commit": true, "done": true,
+}
+
+// conventionalSubjectPattern matches subjects such as "feat(api): add pagination"
+var conventionalSubjectPattern = regexp.MustCompile(`^[a-zA-Z]+(\([^)]*\))?!?: \S`)
+
+// ScoreCommitMessage rates how much a commit message explains the change, from
+// 0 to 100. Longer, descriptive subjects, a body and the conventional commit
+// format all raise the score; one-word subjects like "fix" or "wip" keep it low.
+func ScoreCommitMessage(subject, body string) int {
+	subject = strings.TrimSpace(subject)
+	body = strings.TrimSpace(body)
+
+	description := subject
+	if conventionalSubjectPattern.MatchString(subject) {
+		description = strings.TrimSpace(subject[strings.Index(subject, ":")+1:])
+	}
+
+	normalized := strings.ToLower(strings.Trim(description, " .!:-"))
+	if normalized == "" || vagueSubjects[normalized] {
+		if len(body) >= 20 {
+			return 30
+		}
+		return 0
+	}
+
+	score := 0
+
+	switch words := len(strings.Fields(description)); {
+	case words >= 4:
+		score += 40
+	case words >= 2:
+		score += 20
+	}
+
+	if len(description) >= 20 {
+		score += 10
+	}
+
+	if len(body) >= 20 {
+		score += 30
+	}
+
+	if conventionalSubjectPattern.MatchString(subject) {
+		score += 20
+	}
+
+	if score > 100 {
+		score = 100
+	}
+	return score
+}
+
+// AverageMessageScore returns the mean ScoreCommitMessage of the given commits
+func AverageMessageScore(commits []Commit) int {
+	if len(commits) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, commit := range commits {
+		total += ScoreCommitMessage(commit.Subject, commit.Body)
+	}
+	return total / len(commits)
+}
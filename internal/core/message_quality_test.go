@@ -0,0 +1,28 @@
+package core
+
+import "testing"
+
+func TestScoreCommitMessage(t *testing.T) {
+	tests := []struct {
+		subject string
+		body    string
+		vague   bool
+	}{
+		{"fix", "", true},
+		{"WIP", "", true},
+		{"update.", "", true},
+		{"chore: update", "", true},
+		{"Fix bug", "", true},
+		{"Add pagination to the commit listing", "", false},
+		{"feat(listing): add pagination", "", false},
+		{"fix", "The parser dropped the last commit when the log ended without a newline", true},
+		{"Handle empty repositories", "git log fails with exit code 128 when there are no commits yet", false},
+	}
+
+	for _, tt := range tests {
+		score := ScoreCommitMessage(tt.subject, tt.body)
+		if vague := score < VagueMessageThreshold; vague != tt.vague {
+			t.Errorf("ScoreCommitMessage(%q, %q) = %d, expected vague=%v", tt.subject, tt.body, score, tt.vague)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OutputFile is a previously saved content file found in the output directory
+type OutputFile struct {
+	Path    string
+	Name    string
+	Format  string
+	ModTime time.Time
+	Size    int64
+}
+
+// ListOutputFiles returns the content files in dir, newest first. Files are
+// recognized by the suffix of the default topic_format.ext naming: suffixes
+// maps each filename suffix, like "_blog_article.md", to its format name.
+// Other files, and subdirectories, are skipped.
+func ListOutputFiles(dir string, suffixes map[string]string) ([]OutputFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output directory %s: %w", dir, err)
+	}
+
+	var files []OutputFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		format := outputFormat(entry.Name(), suffixes)
+		if format == "" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The file was removed while listing
+			continue
+		}
+		files = append(files, OutputFile{
+			Path:    filepath.Join(dir, entry.Name()),
+			Name:    entry.Name(),
+			Format:  format,
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+		})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+	return files, nil
+}
+
+// NextFreePath returns filePath if nothing exists there, otherwise the first
+// free numbered variant, e.g. post_2.md, then post_3.md
+func NextFreePath(filePath string) string {
+	if _, err := os.Lstat(filePath); err != nil {
+		return filePath
+	}
+	ext := filepath.Ext(filePath)
+	base := strings.TrimSuffix(filePath, ext)
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s_%d%s", base, n, ext)
+		if _, err := os.Lstat(candidate); err != nil {
+			return candidate
+		}
+	}
+}
+
+// outputFormat returns the format of the file named name, matching the
+// longest suffix so "_docs.md" can't shadow "_technical_docs.md"
+func outputFormat(name string, suffixes map[string]string) string {
+	var format, matched string
+	lower := strings.ToLower(name)
+	for suffix, suffixFormat := range suffixes {
+		if len(suffix) > len(matched) && len(lower) > len(suffix) && strings.HasSuffix(lower, suffix) {
+			format, matched = suffixFormat, suffix
+		}
+	}
+	return format
+}
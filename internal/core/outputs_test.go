@@ -0,0 +1,79 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListOutputFiles(t *testing.T) {
+	dir := t.TempDir()
+	suffixes := map[string]string{
+		"_blog_article.md":    "Blog Article",
+		"_twitter_thread.txt": "Twitter Thread",
+	}
+
+	write := func(name string, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set the time of %s: %v", name, err)
+		}
+	}
+	write("faster_diffs_blog_article.md", 2*time.Hour)
+	write("release_notes_twitter_thread.txt", time.Hour)
+	write("notes.md", 0)
+	write("_blog_article.md", 0)
+	if err := os.Mkdir(filepath.Join(dir, "old_blog_article.md"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	files, err := ListOutputFiles(dir, suffixes)
+	if err != nil {
+		t.Fatalf("ListOutputFiles failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 output files, got %+v", files)
+	}
+	if files[0].Name != "release_notes_twitter_thread.txt" || files[0].Format != "Twitter Thread" {
+		t.Errorf("Expected the newest file first, got %+v", files[0])
+	}
+	if files[1].Format != "Blog Article" || files[1].Path != filepath.Join(dir, "faster_diffs_blog_article.md") {
+		t.Errorf("Unexpected second file %+v", files[1])
+	}
+
+	if _, err := ListOutputFiles(filepath.Join(dir, "missing"), suffixes); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestNextFreePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post.md")
+	if got := NextFreePath(path); got != path {
+		t.Errorf("Expected a free path to be kept, got %s", got)
+	}
+
+	for _, name := range []string{"post.md", "post_2.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if got := NextFreePath(path); got != filepath.Join(dir, "post_3.md") {
+		t.Errorf("Expected post_3.md, got %s", got)
+	}
+}
+
+func TestOutputFormatPrefersLongestSuffix(t *testing.T) {
+	suffixes := map[string]string{
+		"_docs.md":           "Docs",
+		"_technical_docs.md": "Technical Docs",
+	}
+	if format := outputFormat("api_technical_docs.md", suffixes); format != "Technical Docs" {
+		t.Errorf("Expected Technical Docs, got %q", format)
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OutputDirEnvVar names the directory generated content is saved to by
+// default, instead of the current directory
+const OutputDirEnvVar = "COMMITLORE_OUTPUT_DIR"
+
+// DataDir returns a writable directory for CommitLore's logs, configuration
+// and history, creating it if needed. COMMITLORE_HOME takes precedence,
+// followed by ~/.commitlore, $XDG_STATE_HOME/commitlore and finally a
+// commitlore directory under $TMPDIR, so sandboxes without a usable home
+// directory still work.
+func DataDir() (string, error) {
+	var candidates []string
+	if dir := os.Getenv("COMMITLORE_HOME"); dir != "" {
+		candidates = append(candidates, dir)
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(homeDir, ".commitlore"))
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		candidates = append(candidates, filepath.Join(dir, "commitlore"))
+	}
+	candidates = append(candidates, filepath.Join(os.TempDir(), "commitlore"))
+
+	var lastErr error
+	for _, dir := range candidates {
+		if err := ensureWritableDir(dir); err != nil {
+			lastErr = err
+			continue
+		}
+		return dir, nil
+	}
+
+	return "", fmt.Errorf("no writable data directory found: %w", lastErr)
+}
+
+// ExpandHome replaces a leading ~ in path with the user's home directory
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot expand ~: %w", err)
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// OutputDir returns the directory generated content is saved to by default:
+// COMMITLORE_OUTPUT_DIR, created if needed, or else the current directory
+func OutputDir() (string, error) {
+	dir := strings.TrimSpace(os.Getenv(OutputDirEnvVar))
+	if dir == "" {
+		return os.Getwd()
+	}
+
+	dir, err := ExpandHome(dir)
+	if err != nil {
+		return "", err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", OutputDirEnvVar, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// ensureWritableDir creates dir if needed and checks that files can be created in it
+func ensureWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	file, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("directory %s is not writable: %w", dir, err)
+	}
+	name := file.Name()
+	file.Close()
+	os.Remove(name)
+
+	return nil
+}
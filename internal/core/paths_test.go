@@ -0,0 +1,71 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestOutputDir(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	t.Setenv(OutputDirEnvVar, "")
+	if dir, err := OutputDir(); err != nil || dir != cwd {
+		t.Errorf("Expected the working directory %s, got %s (%v)", cwd, dir, err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "drafts", "2026")
+	t.Setenv(OutputDirEnvVar, dir)
+	if got, err := OutputDir(); err != nil || got != dir {
+		t.Errorf("Expected %s, got %s (%v)", dir, got, err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("Expected the output directory to be created: %v", err)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(OutputDirEnvVar, "~/drafts")
+	if got, err := OutputDir(); err != nil || got != filepath.Join(home, "drafts") {
+		t.Errorf("Expected ~ to expand to %s, got %s (%v)", home, got, err)
+	}
+}
+
+func TestDataDirOverride(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	t.Setenv("COMMITLORE_HOME", dir)
+
+	got, err := DataDir()
+	if err != nil {
+		t.Fatalf("DataDir failed: %v", err)
+	}
+	if got != dir {
+		t.Errorf("Expected %s, got %s", dir, got)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("Expected data directory to be created: %v", err)
+	}
+}
+
+func TestDataDirFallsBackWithoutHome(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("home directory resolution differs on Windows")
+	}
+
+	stateDir := t.TempDir()
+	t.Setenv("COMMITLORE_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("XDG_STATE_HOME", stateDir)
+
+	got, err := DataDir()
+	if err != nil {
+		t.Fatalf("DataDir failed: %v", err)
+	}
+	if expected := filepath.Join(stateDir, "commitlore"); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// RepoRemoteURL returns the HTTPS web address of the repository's origin
+// remote, e.g. https://github.com/owner/repo, for linking issues and commits.
+// It is empty when there is no origin or it isn't hosted on GitHub or GitLab.
+func RepoRemoteURL(repoPath string) (string, error) {
+	output, err := exec.Command("git", "-C", repoPath, "config", "--get", "remote.origin.url").Output()
+	if err != nil {
+		// git config exits with 1 when the key isn't set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read the origin remote: %w", err)
+	}
+	return NormalizeRemoteURL(strings.TrimSpace(string(output))), nil
+}
+
+// NormalizeRemoteURL turns a GitHub or GitLab remote URL, in HTTPS, SSH or
+// scp-like git@host:owner/repo.git form, into the repository's HTTPS web
+// address without credentials or the .git suffix. Other hosts give "".
+func NormalizeRemoteURL(remote string) string {
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		host, path = parsed.Hostname(), parsed.Path
+	} else if before, after, ok := strings.Cut(remote, ":"); ok && !strings.Contains(before, "/") {
+		// scp-like syntax, user@host:path
+		host, path = before[strings.LastIndex(before, "@")+1:], after
+	} else {
+		return ""
+	}
+
+	host = strings.ToLower(host)
+	if !strings.Contains(host, "github") && !strings.Contains(host, "gitlab") {
+		return ""
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return ""
+	}
+	return fmt.Sprintf("https://%s/%s", host, path)
+}
+
+// IssueURLPrefix returns the address that an issue number is appended to for
+// a repository's web address from RepoRemoteURL. GitHub redirects issue
+// numbers that are pull requests, while GitLab issues live under /-/issues.
+func IssueURLPrefix(remoteURL string) string {
+	if remoteURL == "" {
+		return ""
+	}
+	if strings.Contains(remoteURL, "gitlab") {
+		return remoteURL + "/-/issues/"
+	}
+	return remoteURL + "/issues/"
+}
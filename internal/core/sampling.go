@@ -0,0 +1,135 @@
+package core
+
+import (
+	"math"
+	"strings"
+)
+
+// EstimateChangesetTokens estimates the number of tokens a changeset takes up
+// when sent to an LLM, including its metadata, file list and diff
+func EstimateChangesetTokens(changeset Changeset) int {
+	text := changeset.Subject + changeset.Body + changeset.Diff + strings.Join(changeset.Files, ", ")
+	// Account for the labels and separators added around each changeset
+	return EstimateTokenCount(text) + 30
+}
+
+// SampleChangesets picks a representative subset of changesets that fits in
+// the given token budget. Changesets are chosen greedily, favouring large
+// diffs, files not yet covered by the sample, conventional commit types like
+// feat and fix over chores, and commits far away in time from those already
+// picked, so the sample spreads across the whole range.
+// The result keeps the original order of the input.
+func SampleChangesets(changesets []Changeset, budget int) []Changeset {
+	if len(changesets) == 0 || budget <= 0 {
+		return []Changeset{}
+	}
+
+	tokens := make([]int, len(changesets))
+	total := 0
+	maxDiffTokens := 0
+	maxFiles := 0
+	for i, changeset := range changesets {
+		tokens[i] = EstimateChangesetTokens(changeset)
+		total += tokens[i]
+		if diffTokens := EstimateTokenCount(changeset.Diff); diffTokens > maxDiffTokens {
+			maxDiffTokens = diffTokens
+		}
+		if len(changeset.Files) > maxFiles {
+			maxFiles = len(changeset.Files)
+		}
+	}
+
+	if total <= budget {
+		return append([]Changeset{}, changesets...)
+	}
+
+	earliest, latest := changesets[0].Date, changesets[0].Date
+	for _, changeset := range changesets {
+		if changeset.Date.Before(earliest) {
+			earliest = changeset.Date
+		}
+		if changeset.Date.After(latest) {
+			latest = changeset.Date
+		}
+	}
+	span := latest.Sub(earliest).Seconds()
+
+	selected := make([]bool, len(changesets))
+	var selectedIndices []int
+	coveredFiles := make(map[string]bool)
+	remaining := budget
+
+	for {
+		best := -1
+		bestScore := -1.0
+
+		for i, changeset := range changesets {
+			if selected[i] || tokens[i] > remaining {
+				continue
+			}
+
+			// Larger diffs usually carry the more significant changes
+			sizeScore := 0.0
+			if maxDiffTokens > 0 {
+				sizeScore = math.Log1p(float64(EstimateTokenCount(changeset.Diff))) / math.Log1p(float64(maxDiffTokens))
+			}
+
+			// Prefer commits that touch files the sample doesn't cover yet
+			fileScore := 0.0
+			if maxFiles > 0 {
+				newFiles := 0
+				for _, file := range changeset.Files {
+					if !coveredFiles[file] {
+						newFiles++
+					}
+				}
+				fileScore = float64(newFiles) / float64(maxFiles)
+			}
+
+			// Prefer commits far away in time from those already picked
+			timeScore := 1.0
+			if len(selectedIndices) > 0 && span > 0 {
+				nearest := span
+				for _, j := range selectedIndices {
+					distance := math.Abs(changeset.Date.Sub(changesets[j].Date).Seconds())
+					if distance < nearest {
+						nearest = distance
+					}
+				}
+				timeScore = nearest / span
+			}
+
+			// Prefer features and fixes over chores when commits are conventional
+			typeScore := defaultConventionalWeight
+			if conventional, ok := ParseConventionalCommit(changeset.Subject); ok {
+				typeScore = ConventionalTypeWeight(conventional.Type)
+			}
+
+			score := sizeScore + fileScore + timeScore + typeScore
+			if score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+
+		if best == -1 {
+			break
+		}
+
+		selected[best] = true
+		selectedIndices = append(selectedIndices, best)
+		remaining -= tokens[best]
+		for _, file := range changesets[best].Files {
+			coveredFiles[file] = true
+		}
+	}
+
+	sample := make([]Changeset, 0, len(selectedIndices))
+	for i, changeset := range changesets {
+		if selected[i] {
+			sample = append(sample, changeset)
+		}
+	}
+
+	return sample
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampleChangesets(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	changeset := func(i int, subject string, diffLines int, files ...string) Changeset {
+		return Changeset{
+			CommitHash: fmt.Sprintf("hash%d", i),
+			Date:       start.Add(time.Duration(i) * 24 * time.Hour),
+			Subject:    subject,
+			Diff:       strings.Repeat("+ a changed line of code\n", diffLines),
+			Files:      files,
+		}
+	}
+
+	t.Run("Empty input or budget", func(t *testing.T) {
+		if sample := SampleChangesets(nil, 1000); len(sample) != 0 {
+			t.Errorf("Expected no changesets, got %d", len(sample))
+		}
+		if sample := SampleChangesets([]Changeset{changeset(0, "feat: a", 1, "a.go")}, 0); len(sample) != 0 {
+			t.Errorf("Expected no changesets for a zero budget, got %d", len(sample))
+		}
+	})
+
+	t.Run("Everything fits", func(t *testing.T) {
+		changesets := []Changeset{changeset(0, "feat: a", 1, "a.go"), changeset(1, "fix: b", 1, "b.go")}
+		sample := SampleChangesets(changesets, 100000)
+		if len(sample) != 2 || sample[0].CommitHash != "hash0" || sample[1].CommitHash != "hash1" {
+			t.Errorf("Expected both changesets in order, got %+v", sample)
+		}
+	})
+
+	t.Run("Sample fits the budget and keeps the order", func(t *testing.T) {
+		var changesets []Changeset
+		for i := 0; i < 20; i++ {
+			changesets = append(changesets, changeset(i, fmt.Sprintf("feat: change %d", i), 10+i, fmt.Sprintf("file%d.go", i)))
+		}
+		budget := EstimateChangesetTokens(changesets[19]) * 5
+		sample := SampleChangesets(changesets, budget)
+		if len(sample) == 0 || len(sample) == len(changesets) {
+			t.Fatalf("Expected a partial sample, got %d changesets", len(sample))
+		}
+
+		used := 0
+		for i, picked := range sample {
+			used += EstimateChangesetTokens(picked)
+			if i > 0 && !picked.Date.After(sample[i-1].Date) {
+				t.Errorf("Expected the sample in input order, got %s after %s", picked.CommitHash, sample[i-1].CommitHash)
+			}
+		}
+		if used > budget {
+			t.Errorf("Expected the sample to fit %d tokens, used %d", budget, used)
+		}
+	})
+
+	t.Run("Prefers larger diffs and features over chores", func(t *testing.T) {
+		small := changeset(0, "feat: small", 2, "a.go")
+		large := changeset(0, "feat: large", 40, "a.go")
+		sample := SampleChangesets([]Changeset{small, large}, EstimateChangesetTokens(large)+1)
+		if len(sample) != 1 || sample[0].Subject != "feat: large" {
+			t.Errorf("Expected the larger diff, got %+v", sample)
+		}
+
+		chore := changeset(0, "chore: tidy up", 10, "a.go")
+		feature := changeset(0, "feat: tidy up!", 10, "a.go")
+		sample = SampleChangesets([]Changeset{chore, feature}, EstimateChangesetTokens(feature)+1)
+		if len(sample) != 1 || sample[0].Subject != "feat: tidy up!" {
+			t.Errorf("Expected the feature over the chore, got %+v", sample)
+		}
+	})
+}
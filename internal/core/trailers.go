@@ -0,0 +1,153 @@
+package core
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TrailersEnvVar, when set to false, leaves co-authors and issue references
+// parsed from commit trailers out of the prompt
+const TrailersEnvVar = "COMMITLORE_TRAILERS"
+
+// trailerLine matches a `Key: value` trailer line, e.g. "Signed-off-by: A <a@b>"
+var trailerLine = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*):\s*(.*)$`)
+
+// issueReference matches #123 and owner/repo#123 style references in a
+// commit message
+var issueReference = regexp.MustCompile(`(?:\b[\w.-]+/[\w.-]+)?#\d+\b`)
+
+// referenceTrailers are the trailers whose values point at an issue or PR
+var referenceTrailers = []string{"Fixes", "Closes", "Resolves", "Refs", "References", "Issue", "See-also"}
+
+// ListedTrailers are the trailers the changelist shows on lines of their own,
+// the co-authors and issue references, so they are left out of the body there
+var ListedTrailers = append([]string{"Co-authored-by"}, referenceTrailers...)
+
+// IncludeTrailers reports whether co-authors and issue references go into the
+// prompt, which is the default unless COMMITLORE_TRAILERS is false
+func IncludeTrailers() bool {
+	include, err := strconv.ParseBool(os.Getenv(TrailersEnvVar))
+	return err != nil || include
+}
+
+// ParseTrailers splits the trailer block off a commit body. Like git, it
+// takes the trailers from the last paragraph when every line in it is a
+// `Key: value` trailer or the indented continuation of one. Keys are
+// normalized to git's usual casing, e.g. "Co-authored-by". The body is
+// returned without the trailer block, and unchanged when there is none.
+func ParseTrailers(body string) (string, map[string][]string) {
+	rest, entries, ok := trailerBlock(body)
+	if !ok {
+		return strings.TrimSpace(body), nil
+	}
+
+	trailers := make(map[string][]string)
+	for _, entry := range entries {
+		lines := strings.Split(entry, "\n")
+		match := trailerLine.FindStringSubmatch(strings.TrimRight(lines[0], " \t\r"))
+		value := strings.TrimSpace(match[2])
+		for _, continuation := range lines[1:] {
+			value += " " + strings.TrimSpace(continuation)
+		}
+		key := normalizeTrailerKey(match[1])
+		trailers[key] = append(trailers[key], value)
+	}
+	return rest, trailers
+}
+
+// StripTrailers removes the trailers with the given keys from the trailer
+// block of a commit body, keeping the rest of the body and other trailers,
+// such as Signed-off-by, as they are
+func StripTrailers(body string, keys []string) string {
+	rest, entries, ok := trailerBlock(body)
+	if !ok {
+		return strings.TrimSpace(body)
+	}
+
+	var kept []string
+	for _, entry := range entries {
+		match := trailerLine.FindStringSubmatch(strings.TrimRight(strings.SplitN(entry, "\n", 2)[0], " \t\r"))
+		key := normalizeTrailerKey(match[1])
+		if !slices.Contains(keys, key) {
+			kept = append(kept, entry)
+		}
+	}
+	switch {
+	case len(kept) == 0:
+		return rest
+	case rest == "":
+		return strings.Join(kept, "\n")
+	}
+	return rest + "\n\n" + strings.Join(kept, "\n")
+}
+
+// trailerBlock splits a commit body into the text before its trailer block
+// and the block's entries, each a trailer line followed by its continuation
+// lines. ok is false when the last paragraph isn't a trailer block.
+func trailerBlock(body string) (string, []string, bool) {
+	body = strings.TrimSpace(body)
+	start := strings.LastIndex(body, "\n\n")
+	paragraph := body[start+1:]
+	if start < 0 {
+		paragraph = body
+	}
+
+	var entries []string
+	for _, line := range strings.Split(strings.TrimSpace(paragraph), "\n") {
+		if len(entries) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			entries[len(entries)-1] += "\n" + line
+			continue
+		}
+		if !trailerLine.MatchString(strings.TrimRight(line, " \t\r")) {
+			return body, nil, false
+		}
+		entries = append(entries, line)
+	}
+	if len(entries) == 0 {
+		return body, nil, false
+	}
+	if start < 0 {
+		return "", entries, true
+	}
+	return strings.TrimSpace(body[:start]), entries, true
+}
+
+// normalizeTrailerKey capitalizes the first letter of a trailer key and
+// lowercases the rest, so "Co-Authored-By" and "co-authored-by" are one key
+func normalizeTrailerKey(key string) string {
+	return strings.ToUpper(key[:1]) + strings.ToLower(key[1:])
+}
+
+// CoAuthors returns the Co-authored-by trailers of a changeset
+func (c Changeset) CoAuthors() []string {
+	return c.Trailers["Co-authored-by"]
+}
+
+// IssueReferences collects the issues and PRs a changeset points at: the
+// values of trailers like Fixes and Refs, then #123 style references in the
+// subject and body. Duplicates are dropped and the order is kept.
+func (c Changeset) IssueReferences() []string {
+	var references []string
+	seen := make(map[string]bool)
+	add := func(reference string) {
+		if reference != "" && !seen[reference] {
+			seen[reference] = true
+			references = append(references, reference)
+		}
+	}
+
+	for _, key := range referenceTrailers {
+		for _, value := range c.Trailers[key] {
+			add(value)
+		}
+	}
+	for _, text := range []string{c.Subject, c.Body} {
+		for _, reference := range issueReference.FindAllString(text, -1) {
+			add(reference)
+		}
+	}
+	return references
+}
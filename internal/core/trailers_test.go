@@ -0,0 +1,98 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+		trailers map[string][]string
+	}{
+		{
+			name:     "Body and trailers",
+			body:     "Retry on 429s.\n\nCo-authored-by: Ana <ana@example.com>\nSigned-off-by: Ben <ben@example.com>\nco-authored-by: Cy <cy@example.com>",
+			expected: "Retry on 429s.",
+			trailers: map[string][]string{
+				"Co-authored-by": {"Ana <ana@example.com>", "Cy <cy@example.com>"},
+				"Signed-off-by":  {"Ben <ben@example.com>"},
+			},
+		},
+		{
+			name:     "Trailers only",
+			body:     "Fixes: #12",
+			expected: "",
+			trailers: map[string][]string{"Fixes": {"#12"}},
+		},
+		{
+			name:     "Continuation line",
+			body:     "Body\n\nSee-also: a very long\n  reference",
+			expected: "Body",
+			trailers: map[string][]string{"See-also": {"a very long reference"}},
+		},
+		{
+			name:     "Prose last paragraph",
+			body:     "Note: this is not a trailer block\nbecause this line is prose",
+			expected: "Note: this is not a trailer block\nbecause this line is prose",
+		},
+		{
+			name:     "Empty",
+			body:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, trailers := ParseTrailers(tt.body)
+			if body != tt.expected {
+				t.Errorf("Expected body %q, got %q", tt.expected, body)
+			}
+			if len(trailers) != len(tt.trailers) {
+				t.Fatalf("Expected trailers %v, got %v", tt.trailers, trailers)
+			}
+			for key, values := range tt.trailers {
+				if strings.Join(trailers[key], "|") != strings.Join(values, "|") {
+					t.Errorf("Expected %s %q, got %q", key, values, trailers[key])
+				}
+			}
+		})
+	}
+}
+
+func TestStripTrailers(t *testing.T) {
+	body := "Retry on 429s.\n\nCo-authored-by: Ana <ana@example.com>\nSigned-off-by: Ben <ben@example.com>\nFixes: #12\nSee-also: a very long\n  reference"
+	if got := StripTrailers(body, ListedTrailers); got != "Retry on 429s.\n\nSigned-off-by: Ben <ben@example.com>" {
+		t.Errorf("Expected only Signed-off-by to be kept, got %q", got)
+	}
+	if got := StripTrailers("Fixes: #12", ListedTrailers); got != "" {
+		t.Errorf("Expected an empty body, got %q", got)
+	}
+	if got := StripTrailers("Signed-off-by: Ben <ben@example.com>", ListedTrailers); got != "Signed-off-by: Ben <ben@example.com>" {
+		t.Errorf("Expected the trailer to be kept, got %q", got)
+	}
+	prose := "Note: this is not a trailer block\nbecause this line is prose"
+	if got := StripTrailers(prose, ListedTrailers); got != prose {
+		t.Errorf("Expected prose to be kept, got %q", got)
+	}
+}
+
+func TestIssueReferences(t *testing.T) {
+	changeset := Changeset{
+		Subject:  "fix: handle empty repos (#41)",
+		Body:     "Follow-up to acme/api#7, see #41.",
+		Trailers: map[string][]string{"Fixes": {"#40"}, "Refs": {"PROJ-123"}},
+	}
+
+	got := strings.Join(changeset.IssueReferences(), ", ")
+	if got != "#40, PROJ-123, #41, acme/api#7" {
+		t.Errorf("Unexpected references %q", got)
+	}
+
+	if refs := (Changeset{Subject: "chore: tidy"}).IssueReferences(); len(refs) != 0 {
+		t.Errorf("Expected no references, got %v", refs)
+	}
+}
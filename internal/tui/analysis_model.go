@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
+)
+
+// analysisTimeout bounds how long the analysis request may run
+const analysisTimeout = 2 * time.Minute
+
+// AnalysisModel handles the analyze only view, which runs
+// llm.CommitAnalysisPrompt over the selected commits and shows the
+// structured result instead of turning it into content
+type AnalysisModel struct {
+	BaseModel
+	commits         []core.Commit
+	selectedCommits map[int]bool
+	changelistMode  changelistMode
+	metadataOnly    map[string]bool
+
+	isAnalyzing    bool
+	cancelAnalysis context.CancelFunc // Cancels the in-flight analysis, set while analyzing
+	startTime      time.Time
+	hourglassFrame int
+
+	// analysis is the parsed result, and response the raw one, shown when it
+	// can't be parsed or during a dry run
+	analysis *llm.CommitAnalysis
+	response string
+	viewport viewport.Model
+}
+
+// NewAnalysisModel creates a new analysis model
+func NewAnalysisModel(base BaseModel) *AnalysisModel {
+	return &AnalysisModel{
+		BaseModel: base,
+		viewport:  viewport.New(94, 14),
+	}
+}
+
+func (m *AnalysisModel) Init() tea.Cmd {
+	return nil
+}
+
+// Analyze starts the analysis of the selected commits
+func (m *AnalysisModel) Analyze(commits []core.Commit, selectedCommits map[int]bool, mode changelistMode, metadataOnly map[string]bool) tea.Cmd {
+	m.commits = commits
+	m.selectedCommits = selectedCommits
+	m.changelistMode = mode
+	m.metadataOnly = metadataOnly
+	return m.analyze()
+}
+
+// analyze sends the stored commits to the LLM with the analysis prompt
+func (m *AnalysisModel) analyze() tea.Cmd {
+	logger := core.GetLogger()
+	logger.Info("Starting commit analysis", "selected_commits", len(m.selectedCommits), "provider", m.providerName())
+
+	m.errorMsg = ""
+	m.statusMessage = nil
+	m.analysis = nil
+	m.response = ""
+
+	if m.llmProvider == nil {
+		m.errorMsg = "LLM provider not configured"
+		logger.Error("LLM provider not configured for commit analysis")
+		return nil
+	}
+
+	changelistData := buildChangelistData(m.repoPath, m.commits, m.selectedCommits, changelistOptions{
+		mode:         m.changelistMode,
+		scope:        changelistScopeFull,
+		hashLength:   m.hashLength,
+		metadataOnly: m.metadataOnly,
+	})
+	userPrompt := fmt.Sprintf("Analyze these commits and respond with the JSON object only:\n\n%s", changelistData)
+
+	promptTokens := core.EstimateTokenCount(llm.CommitAnalysisPrompt) + core.EstimateTokenCount(userPrompt)
+	if err := llm.CheckPromptSize(m.providerName(), m.modelName(), promptTokens, m.maxTokens()); err != nil {
+		logger.Warn("Not sending a prompt larger than the context window", "prompt_tokens", promptTokens, "error", err)
+		m.statusMessage = NewWarningMessage(promptTooLargeMessage(err))
+		return nil
+	}
+
+	m.isAnalyzing = true
+	m.startTime = time.Now()
+	m.hourglassFrame = 0
+
+	responseChan := llm.CreateLLMResponseChannel()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelAnalysis = cancel
+	llm.NewAsyncLLMWrapper(m.llmProvider, analysisTimeout).GenerateContentWithSystemPromptAsync(ctx, llm.CommitAnalysisPrompt, userPrompt, responseChan)
+
+	return tea.Batch(llm.WaitForLLMResponse(responseChan), doTick())
+}
+
+func (m *AnalysisModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case TickMsg:
+		if m.isAnalyzing {
+			m.hourglassFrame = (m.hourglassFrame + 1) % len(hourglassFrames)
+			return m, doTick()
+		}
+		return m, nil
+	case llm.LLMResponseMsg:
+		// Responses to canceled requests were already dealt with on esc
+		if msg.Canceled {
+			return m, nil
+		}
+		m.isAnalyzing = false
+		if m.cancelAnalysis != nil {
+			m.cancelAnalysis()
+			m.cancelAnalysis = nil
+		}
+		if msg.Error != "" {
+			m.errorMsg = msg.Error
+			return m, nil
+		}
+		m.setResponse(msg.Content)
+		return m, nil
+	case clipboardCopiedMsg:
+		if msg.Error != "" {
+			m.statusMessage = NewErrorMessage(msg.Error)
+		} else {
+			m.statusMessage = NewSuccessMessage(fmt.Sprintf("📋 Copied %s to clipboard", msg.What))
+		}
+		return m, clearStatusAfterDelay()
+	case clearStatusMsg:
+		m.statusMessage = nil
+		return m, nil
+	case tea.KeyMsg:
+		if m.isAnalyzing {
+			if msg.String() == "esc" {
+				core.GetLogger().Info("Canceling commit analysis", "elapsed", formatDuration(time.Since(m.startTime)))
+				if m.cancelAnalysis != nil {
+					m.cancelAnalysis()
+					m.cancelAnalysis = nil
+				}
+				m.isAnalyzing = false
+				return m, func() tea.Msg { return BackMsg{} }
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "r":
+			if len(m.selectedCommits) > 0 {
+				return m, m.analyze()
+			}
+		case "c":
+			if m.analysis != nil {
+				data, err := json.MarshalIndent(m.analysis, "", "  ")
+				if err != nil {
+					m.statusMessage = NewErrorMessage(fmt.Sprintf("Failed to encode the analysis: %v", err))
+					return m, clearStatusAfterDelay()
+				}
+				return m, copyToClipboard(string(data), "analysis JSON")
+			}
+		case "esc":
+			return m, func() tea.Msg { return BackMsg{} }
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// setResponse parses the analysis out of a response and shows it, or shows
+// the raw response when it isn't valid analysis JSON
+func (m *AnalysisModel) setResponse(response string) {
+	m.response = response
+	m.viewport.Width = m.layout.wrapWidth()
+	m.viewport.Height = m.layout.viewportHeight(14)
+	m.viewport.GotoTop()
+
+	if m.dryRun {
+		m.viewport.SetContent(wordwrap.String(response, m.layout.wrapWidth()))
+		return
+	}
+
+	analysis, err := llm.ParseCommitAnalysis(response)
+	if err != nil {
+		core.GetLogger().Warn("Failed to parse the commit analysis", "error", err, "response", core.LogExcerpt(response))
+		m.errorMsg = fmt.Sprintf("Couldn't read the analysis: %v. The response is shown as is.", err)
+		m.viewport.SetContent(wordwrap.String(response, m.layout.wrapWidth()))
+		return
+	}
+	m.analysis = analysis
+	m.viewport.SetContent(renderAnalysis(analysis, m.layout.wrapWidth()))
+}
+
+// renderAnalysis lays out the summary and findings of an analysis
+func renderAnalysis(analysis *llm.CommitAnalysis, width int) string {
+	var sections []string
+	if analysis.Summary != "" {
+		sections = append(sections, wordwrap.String(analysis.Summary, width))
+	}
+
+	for i, finding := range analysis.Findings {
+		title := finding.Title
+		if title == "" {
+			title = fmt.Sprintf("Finding %d", i+1)
+		}
+		lines := []string{selectedSubjectStyle.Render(fmt.Sprintf("%d. %s", i+1, title))}
+		if finding.Description != "" {
+			lines = append(lines, wordwrap.String(finding.Description, width))
+		}
+		field := func(label, value string) {
+			if value != "" {
+				lines = append(lines, wordwrap.String(authorStyle.Render(label+": ")+value, width))
+			}
+		}
+		field("🏆 Achievement", finding.Challenge)
+		field("🧰 Skills", strings.Join(finding.Skills, " · "))
+		field("📈 Impact", finding.Impact)
+		field("🔗 Commits", strings.Join(finding.Commits, ", "))
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func (m *AnalysisModel) View() string {
+	header := titleStyle.Render("🔬 Commit Analysis")
+	subtitle := subtitleStyle.Render(fmt.Sprintf("Technical achievements, skills and impact across %d commits", len(m.selectedCommits)))
+	switch {
+	case m.isAnalyzing:
+		subtitle = subtitleStyle.Render(fmt.Sprintf("🤖 Analyzing commits with %s... %s (%s)",
+			m.providerStatus(), hourglassFrames[m.hourglassFrame], formatDuration(time.Since(m.startTime))))
+	case m.analysis != nil:
+		subtitle = subtitleStyle.Render(fmt.Sprintf("%d findings across %d commits", len(m.analysis.Findings), len(m.selectedCommits)))
+	case m.dryRun && m.response != "":
+		subtitle = subtitleStyle.Render("🧪 Analysis prompt (dry run, nothing was sent)")
+	}
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	var sections []string
+	if m.errorMsg != "" {
+		sections = append(sections, errorStyle.Render(fmt.Sprintf("⚠ %s", m.errorMsg)))
+	}
+	if m.response != "" {
+		sections = append(sections, commitRowStyle.Width(m.layout.boxWidth()).Padding(1).Render(m.viewport.View()))
+	}
+	if m.statusMessage != nil {
+		sections = append(sections, RenderStatusMessage(m.statusMessage))
+	}
+
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
+	var helpItems []string
+	if m.isAnalyzing {
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		helpItems = []string{cancelHelp, " • ", quitHelp}
+	} else {
+		if m.response != "" {
+			helpItems = append(helpItems, fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓"), helpDescStyle.Render("scroll")), " • ")
+		}
+		if m.analysis != nil {
+			helpItems = append(helpItems, fmt.Sprintf("%s %s", helpKeyStyle.Render("c"), helpDescStyle.Render("copy JSON")), " • ")
+		}
+		retryHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("r"), helpDescStyle.Render("analyze again"))
+		helpItems = append(helpItems, retryHelp, " • ", backHelp, " • ", quitHelp)
+	}
+	statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, helpItems...))
+
+	main := lipgloss.JoinVertical(lipgloss.Left, append(append([]string{headerWithBg}, sections...), statusBar)...)
+	return appStyle.Render(main)
+}
+
+// helpBindings lists the keys for reading, copying and rerunning an analysis
+func (m *AnalysisModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / pgup pgdn", "scroll the analysis"},
+		{"c", "copy the analysis as JSON"},
+		{"r", "analyze again"},
+		{"esc", "cancel analysis, or go back to commits"},
+	}
+}
@@ -1,20 +1,139 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 )
 
-func RunApp() error {
+// Options configures the TUI from command line arguments
+type Options struct {
+	// Range limits the listing to a revision range such as HEAD~10..HEAD
+	Range string
+	// Commit is the full hash of a single commit to analyze, skipping the
+	// listing. It takes precedence over Range.
+	Commit string
+	// DryRun shows the assembled prompts instead of sending them to a provider
+	DryRun bool
+}
+
+func RunApp(opts Options) error {
 	logger := core.GetLogger()
-	logger.Info("Initializing TUI application")
+	logger.Info("Initializing TUI application", "range", opts.Range, "dry_run", opts.DryRun)
 	
-	p := tea.NewProgram(NewAppModel())
+	app := NewAppModel(opts)
+	defer app.Close()
+
+	model := &panicSafeModel{model: app}
+	// Signals are handled here rather than by Bubble Tea, so the log is synced
+	// however the program ends
+	p := tea.NewProgram(model, tea.WithoutSignalHandler())
+	model.program = p
+	defer func() {
+		if err := core.SyncLogger(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error flushing the log: %v\n", err)
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go handleSignals(p, signals)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+
 	_, err := p.Run()
 	if err != nil {
 		logger.Error("TUI program execution failed", "error", err)
+	} else if model.panicked {
+		logDestination := core.GetLogFilePath()
+		if logDestination == "" {
+			logDestination = "stderr"
+		}
+		err = fmt.Errorf("commitlore hit an unexpected error and had to stop, details were written to %s", logDestination)
 	} else {
 		logger.Info("TUI application terminated successfully")
 	}
 	return err
-}
\ No newline at end of file
+}
+
+// handleSignals quits the program on SIGINT or SIGTERM, which restores the
+// terminal. A second signal kills it, for when a view doesn't quit promptly.
+func handleSignals(p *tea.Program, signals <-chan os.Signal) {
+	logger := core.GetLogger()
+	quitting := false
+	for sig := range signals {
+		if quitting {
+			logger.Warn("Received another signal, stopping immediately", "signal", sig.String())
+			p.Kill()
+			continue
+		}
+		logger.Info("Received signal, quitting", "signal", sig.String())
+		quitting = true
+		p.Quit()
+	}
+}
+
+// panicSafeModel wraps the root model so that a panic in Update or View is
+// logged and the program quits cleanly, leaving the terminal in a usable state
+// instead of dumping a stack trace over the UI
+type panicSafeModel struct {
+	model    tea.Model
+	program  *tea.Program
+	panicked bool
+}
+
+func (m *panicSafeModel) Init() tea.Cmd {
+	return m.model.Init()
+}
+
+func (m *panicSafeModel) Update(msg tea.Msg) (result tea.Model, cmd tea.Cmd) {
+	if m.panicked {
+		return m, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.handlePanic("update", r)
+			result, cmd = m, tea.Quit
+		}
+	}()
+
+	m.model, cmd = m.model.Update(msg)
+	return m, cmd
+}
+
+func (m *panicSafeModel) View() (view string) {
+	if m.panicked {
+		return ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.handlePanic("view", r)
+			view = ""
+			// View runs on the event loop, so the quit has to be sent asynchronously
+			if m.program != nil {
+				go m.program.Quit()
+			}
+		}
+	}()
+
+	return m.model.View()
+}
+
+// handlePanic logs a recovered panic along with its stack trace
+func (m *panicSafeModel) handlePanic(phase string, r interface{}) {
+	m.panicked = true
+	logger := core.GetLogger()
+	logger.Error("Recovered from panic in TUI",
+		"phase", phase,
+		"panic", fmt.Sprint(r),
+		"stack", string(debug.Stack()))
+}
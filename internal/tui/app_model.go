@@ -2,40 +2,48 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 	"github.com/sarkarshuvojit/commitlore/internal/core/config"
+	"github.com/sarkarshuvojit/commitlore/internal/core/history"
 	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-// mockLLMProvider provides mock responses when no API key is available
-type mockLLMProvider struct{}
+// newFallbackProvider returns the mock provider used when no configured
+// provider can be created
+func newFallbackProvider() llm.LLMProvider {
+	return llm.NewMockProvider(llm.MockOptions{Name: "Mock (No providers available)"})
+}
+
+// dryRunProvider answers every request with the prompt it was given, so the
+// assembled prompts can be inspected without calling an LLM
+type dryRunProvider struct{}
 
-func (m *mockLLMProvider) GenerateContent(ctx context.Context, prompt string) (string, error) {
-	return m.GenerateContentWithSystemPrompt(ctx, "", prompt)
+func (d *dryRunProvider) ModelInfo() (string, string) {
+	return "Dry run (prompts are not sent)", ""
 }
 
-func (m *mockLLMProvider) GenerateContentWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
-	mockTopics := []string{
-		"Implementing modern Go patterns and best practices",
-		"Building terminal user interfaces with Bubble Tea",
-		"Git repository analysis and commit processing",
-		"Error handling and robust software design",
-		"API integration and external service communication",
-	}
-	
-	result := ""
-	for _, topic := range mockTopics {
-		result += topic + "\n"
-	}
-	
-	return result, nil
+func (d *dryRunProvider) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return d.GenerateContentWithSystemPrompt(ctx, "", prompt)
+}
+
+func (d *dryRunProvider) GenerateContentWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	core.GetLogger().Info("Dry run prompt", "system_prompt", core.LogContent(systemPrompt), "user_prompt", core.LogContent(userPrompt))
+	return formatDryRunPrompt(systemPrompt, userPrompt), nil
+}
+
+// formatDryRunPrompt lays out the system and user prompts for display
+func formatDryRunPrompt(systemPrompt, userPrompt string) string {
+	return fmt.Sprintf("=== System prompt ===\n\n%s\n\n=== User prompt ===\n\n%s", systemPrompt, userPrompt)
 }
 
 // NewAppModel creates a new app model with all sub-models
-func NewAppModel() *AppModel {
+func NewAppModel(opts Options) *AppModel {
 	logger := core.GetLogger()
 	cwd, _ := os.Getwd()
 	gitRoot, isGit, _ := core.GetGitDirectory(cwd)
@@ -55,22 +63,48 @@ func NewAppModel() *AppModel {
 	
 	// Initialize LLM provider using factory
 	var llmProvider llm.LLMProvider
-	var llmProviderType string
 	
-	provider, providerName, err := factory.CreateActiveProvider()
-	if err != nil {
+	mockMode, mockModeSet := os.LookupEnv(llm.MockModeEnvVar)
+	provider, err := factory.CreateActiveProvider()
+	if opts.DryRun {
+		llmProvider = &dryRunProvider{}
+	} else if mockModeSet {
+		mockOpts, err := llm.ParseMockMode(mockMode)
+		if err != nil {
+			logger.Warn("Invalid mock mode, using the default mock", "value", mockMode, "error", err)
+			mockOpts = llm.MockOptions{}
+		}
+		mockOpts.Name = fmt.Sprintf("Mock (%s)", llm.MockModeEnvVar)
+		llmProvider = llm.NewMockProvider(mockOpts)
+	} else if err != nil {
 		logger.Warn("Failed to create active provider, falling back to mock", "error", err)
-		llmProvider = &mockLLMProvider{}
-		llmProviderType = "Mock (No providers available)"
+		llmProvider = newFallbackProvider()
 	} else {
 		llmProvider = provider
-		llmProviderType = providerName
+	}
+	
+	advancedMode, _ := strconv.ParseBool(os.Getenv("COMMITLORE_ADVANCED"))
+	
+	hashLength := core.DefaultHashLength
+	if isGit {
+		hashLength = core.HashLength(gitRoot)
 	}
 	
 	baseModel := BaseModel{
-		repoPath:        gitRoot,
-		llmProvider:     llmProvider,
-		llmProviderType: llmProviderType,
+		repoPath:      gitRoot,
+		llmProvider:   llmProvider,
+		advancedMode:  advancedMode,
+		hashLength:    hashLength,
+		history:       openHistoryStore(),
+		revisionRange: opts.Range,
+		dryRun:        opts.DryRun,
+		mockMode:      mockModeSet,
+		layout:        &layout{},
+	}
+	
+	if opts.Commit != "" {
+		// The listing shows just the commit, for when the user goes back
+		baseModel.revisionRange = opts.Commit + "^!"
 	}
 	
 	if !isGit {
@@ -81,6 +115,7 @@ func NewAppModel() *AppModel {
 		BaseModel:       baseModel,
 		currentView:     SplashView,
 		selectedCommits: make(map[int]bool),
+		singleCommit:    opts.Commit,
 	}
 	
 	// Initialize sub-models
@@ -90,21 +125,77 @@ func NewAppModel() *AppModel {
 	app.formatModel = NewFormatModel(baseModel)
 	app.contentModel = NewContentModel(baseModel)
 	app.providerModel = NewProviderModel(baseModel)
+	app.fileModel = NewFileModel(baseModel)
+	app.historyModel = NewHistoryModel(baseModel)
+	app.branchModel = NewBranchModel(baseModel)
+	app.outputsModel = NewOutputsModel(baseModel)
+	app.analysisModel = NewAnalysisModel(baseModel)
+	
+	if opts.Commit != "" && len(app.listingModel.commits) > 0 {
+		logger.Info("Analyzing a single commit", "commit", opts.Commit)
+		app.listingModel.selectedCommits[0] = true
+		app.currentView = ListingView
+	}
 	
 	return app
 }
 
 func (m *AppModel) Init() tea.Cmd {
+	// A commit given on the command line goes straight to topic extraction
+	if m.singleCommit != "" && m.currentView == ListingView {
+		_, cmd := m.handleNext()
+		return cmd
+	}
 	return m.getCurrentModel().Init()
 }
 
 func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout.width = msg.Width
+		m.layout.height = msg.Height
+		// Inputs are sized when the terminal is, so views that aren't
+		// shown are resized too
+		for _, view := range []tea.Model{m.topicModel, m.contentModel, m.fileModel} {
+			view.Update(msg)
+		}
+		return m, nil
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			return m, tea.Quit
+		case "q":
+			if !m.isCapturingInput() {
+				return m, tea.Quit
+			}
+		case "?":
+			if !m.isCapturingInput() {
+				m.showHelp = !m.showHelp
+				return m, nil
+			}
+		case "esc":
+			if m.showHelp {
+				m.showHelp = false
+				return m, nil
+			}
+		}
+		// Keys don't reach the view while the help overlay is open
+		if m.showHelp {
+			return m, nil
+		}
+	case splashTimerMsg:
+		// Stay on the splash screen while its help is being read
+		if m.showHelp {
+			m.splashModel.stopCountdown()
+			return m, nil
 		}
+	case tokensCountedMsg:
+		// Counts can come back after the listing is left, and are kept for
+		// when it's shown again
+		_, cmd := m.listingModel.Update(msg)
+		return m, cmd
 	case NextMsg:
 		return m.handleNext()
 	case BackMsg:
@@ -115,12 +206,51 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.providerModel.Init()
 		}
 		return m, nil
+	case HistoryMsg:
+		if m.currentView != HistoryView {
+			m.currentView = HistoryView
+			return m, m.historyModel.Init()
+		}
+		return m, nil
+	case OutputsMsg:
+		if m.currentView != OutputsView {
+			m.currentView = OutputsView
+			return m, m.outputsModel.Init()
+		}
+		return m, nil
+	case AnalyzeMsg:
+		// Analyze the selected commits without going on to content
+		commits, selectedCommits := m.listingModel.GetSelectedCommits()
+		m.currentView = AnalysisView
+		return m, m.analysisModel.Analyze(commits, selectedCommits, changelistCommits, m.listingModel.GetMetadataOnly())
+	case BranchMsg:
+		if m.currentView != BranchView {
+			m.currentView = BranchView
+			return m, m.branchModel.Init()
+		}
+		return m, nil
+	case BranchSelectedMsg:
+		m.listingModel.SetRef(msg.Ref)
+		m.currentView = ListingView
+		return m, m.listingModel.Init()
+	case FileMsg:
+		if m.currentView != FileView {
+			m.currentView = FileView
+			return m, m.fileModel.Init()
+		}
+		return m, nil
+	case FileSelectedMsg:
+		m.listingModel.SetPathFilter(msg.Path, msg.Follow)
+		m.currentView = ListingView
+		return m, m.listingModel.Init()
 	case ErrorMsg:
 		m.errorMsg = msg.Error
 		return m, nil
 	case ProviderSelectedMsg:
-		// Provider was changed, reload the base model
-		return m.reloadProvider(msg.ProviderID)
+		// Provider was changed, create it before reloading the base model
+		return m, reloadProvider(msg.ProviderID)
+	case providerChangedMsg:
+		return m.applyProvider(msg)
 	}
 	
 	// Delegate to current view model
@@ -132,9 +262,84 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *AppModel) View() string {
+	if m.isTerminalTooSmall() {
+		return m.renderTerminalTooSmall()
+	}
+	if m.showHelp {
+		return m.renderHelp()
+	}
 	return m.getCurrentModel().View()
 }
 
+// renderHelp renders the help overlay for the current view
+func (m *AppModel) renderHelp() string {
+	var bindings []helpBinding
+	if provider, ok := m.getCurrentModel().(helpProvider); ok {
+		bindings = provider.helpBindings()
+	}
+	return renderHelpOverlay(m.viewTitle(), bindings, m.width, m.height)
+}
+
+// viewTitle names the current view in the help overlay
+func (m *AppModel) viewTitle() string {
+	switch m.currentView {
+	case ListingView:
+		return "Commit list"
+	case TopicSelectionView:
+		return "Topic selection"
+	case FormatSelectionView:
+		return "Format selection"
+	case ContentCreationView:
+		return "Content"
+	case ProviderView:
+		return "Providers"
+	case FileView:
+		return "File history"
+	case HistoryView:
+		return "History"
+	case BranchView:
+		return "Branches"
+	case OutputsView:
+		return "Saved files"
+	case AnalysisView:
+		return "Commit analysis"
+	default:
+		return "Welcome"
+	}
+}
+
+// isTerminalTooSmall reports whether the last known terminal size is below
+// the minimum the layouts can render in. Before the first WindowSizeMsg
+// arrives the size is unknown and the normal view is rendered.
+func (m *AppModel) isTerminalTooSmall() bool {
+	if m.width == 0 && m.height == 0 {
+		return false
+	}
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// renderTerminalTooSmall renders a notice asking the user to enlarge the terminal
+func (m *AppModel) renderTerminalTooSmall() string {
+	notice := lipgloss.NewStyle().
+		Foreground(warningColor).
+		Bold(true).
+		Render(fmt.Sprintf("Please enlarge your terminal (min %d×%d)", minTerminalWidth, minTerminalHeight))
+
+	details := helpDescStyle.Render(fmt.Sprintf("Current size: %d×%d", m.width, m.height))
+	quitHelp := helpDescStyle.Render("Press 'q' or Ctrl+C to quit")
+
+	content := lipgloss.JoinVertical(lipgloss.Center, notice, "", details, quitHelp)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// isCapturingInput reports whether the current view is accepting free text input
+func (m *AppModel) isCapturingInput() bool {
+	if capturer, ok := m.getCurrentModel().(inputCapturer); ok {
+		return capturer.isCapturingInput()
+	}
+	return false
+}
+
 func (m *AppModel) getCurrentModel() ViewInterface {
 	switch m.currentView {
 	case SplashView:
@@ -149,6 +354,16 @@ func (m *AppModel) getCurrentModel() ViewInterface {
 		return m.contentModel
 	case ProviderView:
 		return m.providerModel
+	case FileView:
+		return m.fileModel
+	case HistoryView:
+		return m.historyModel
+	case BranchView:
+		return m.branchModel
+	case OutputsView:
+		return m.outputsModel
+	case AnalysisView:
+		return m.analysisModel
 	default:
 		return m.splashModel
 	}
@@ -168,6 +383,16 @@ func (m *AppModel) setCurrentModel(model tea.Model) {
 		m.contentModel = model.(*ContentModel)
 	case ProviderView:
 		m.providerModel = model.(*ProviderModel)
+	case FileView:
+		m.fileModel = model.(*FileModel)
+	case HistoryView:
+		m.historyModel = model.(*HistoryModel)
+	case BranchView:
+		m.branchModel = model.(*BranchModel)
+	case OutputsView:
+		m.outputsModel = model.(*OutputsModel)
+	case AnalysisView:
+		m.analysisModel = model.(*AnalysisModel)
 	}
 }
 
@@ -182,6 +407,8 @@ func (m *AppModel) handleNext() (tea.Model, tea.Cmd) {
 		m.selectedCommits = selectedCommits
 		
 		// Start async topic extraction
+		m.topicModel.SetChangelistMode(m.listingModel.GetChangelistMode())
+		m.topicModel.SetMetadataOnly(m.listingModel.GetMetadataOnly())
 		cmd := m.topicModel.ExtractTopics(commits, selectedCommits)
 		
 		m.currentView = TopicSelectionView
@@ -195,10 +422,12 @@ func (m *AppModel) handleNext() (tea.Model, tea.Cmd) {
 		return m, m.formatModel.Init()
 		
 	case FormatSelectionView:
-		// Get selected format and move to content creation
-		m.selectedFormat = m.formatModel.GetSelectedFormat()
+		// Get selected formats and move to content creation
+		m.selectedFormats = m.formatModel.GetSelectedFormats()
 		commits, selectedCommits := m.listingModel.GetSelectedCommits()
-		m.contentModel.SetContextWithCommits(m.selectedTopic, m.selectedFormat, commits, selectedCommits)
+		m.contentModel.SetContextWithCommits(m.selectedTopic, m.selectedFormats, commits, selectedCommits)
+		m.contentModel.SetChangelistMode(m.listingModel.GetChangelistMode())
+		m.contentModel.SetMetadataOnly(m.listingModel.GetMetadataOnly())
 		m.currentView = ContentCreationView
 		return m, m.contentModel.Init()
 	}
@@ -211,7 +440,7 @@ func (m *AppModel) handleBack() (tea.Model, tea.Cmd) {
 	case ListingView:
 		m.currentView = SplashView
 		return m, m.splashModel.Init()
-	case TopicSelectionView:
+	case TopicSelectionView, AnalysisView:
 		m.currentView = ListingView
 		return m, m.listingModel.Init()
 	case FormatSelectionView:
@@ -223,12 +452,11 @@ func (m *AppModel) handleBack() (tea.Model, tea.Cmd) {
 	case ProviderView:
 		m.currentView = SplashView
 		return m, m.splashModel.Init()
+	case FileView, HistoryView, BranchView, OutputsView:
+		m.currentView = SplashView
+		return m, m.splashModel.Init()
 	case SplashView:
-		// Clear selections
-		m.selectedCommits = make(map[int]bool)
-		if m.listingModel != nil {
-			m.listingModel.selectedCommits = make(map[int]bool)
-		}
+		// The commit selection is kept for when the listing is opened again
 		return m, nil
 	}
 	
@@ -236,49 +464,77 @@ func (m *AppModel) handleBack() (tea.Model, tea.Cmd) {
 }
 
 // providerChangedMsg is sent when the active provider has been changed
+// and created. Config is nil when the provider configuration couldn't be
+// loaded, and Error is set when the provider couldn't be created.
 type providerChangedMsg struct {
 	ProviderID string
+	Config     *config.ProviderConfig
+	Provider   llm.LLMProvider
+	Error      error
 }
 
-// reloadProvider reloads the provider after a change
-func (m *AppModel) reloadProvider(providerID string) (tea.Model, tea.Cmd) {
-	logger := core.GetLogger()
-	logger.Debug("Reloading provider after configuration change", "provider_id", providerID)
+// reloadProvider loads the provider configuration and creates the selected
+// provider. It runs as a tea.Cmd since the availability checks run commands
+// and reach the network.
+func reloadProvider(providerID string) tea.Cmd {
+	return func() tea.Msg {
+		logger := core.GetLogger()
+		logger.Debug("Reloading provider after configuration change", "provider_id", providerID)
 
-	// Load updated provider configuration
-	providerConfig, err := config.LoadProviderConfig()
-	if err != nil {
-		logger.Error("Failed to reload provider config", "error", err)
-		m.errorMsg = "Failed to reload provider configuration"
-		return m, nil
-	}
+		// Load updated provider configuration
+		providerConfig, err := config.LoadProviderConfig()
+		if err != nil {
+			logger.Error("Failed to reload provider config", "error", err)
+			return providerChangedMsg{ProviderID: providerID, Error: err}
+		}
 
-	// Set the active provider to the selected one
-	providerConfig.ActiveProviderID = providerID
+		// Update provider availability
+		config.UpdateProviderAvailability(providerConfig)
 
-	// Update provider availability
-	config.UpdateProviderAvailability(providerConfig)
+		// Create the selected provider. When it can't be created the previous
+		// provider is kept, and stays the one remembered for next time.
+		previousProviderID := providerConfig.ActiveProviderID
+		providerConfig.ActiveProviderID = providerID
+		provider, err := config.NewProviderFactory(providerConfig).CreateActiveProvider()
+		if err != nil {
+			logger.Warn("Failed to create the selected provider, keeping the previous one", "provider_id", providerID, "error", err)
+			providerConfig.ActiveProviderID = previousProviderID
+		} else if err := config.SaveProviderConfig(providerConfig); err != nil {
+			logger.Warn("Failed to save provider config", "error", err)
+		}
 
-	// Create provider factory
-	factory := config.NewProviderFactory(providerConfig)
+		return providerChangedMsg{ProviderID: providerID, Config: providerConfig, Provider: provider, Error: err}
+	}
+}
 
-	// Create new provider instance
-	provider, providerName, err := factory.CreateActiveProvider()
-	if err != nil {
-		logger.Warn("Failed to create active provider after reload, falling back to mock", "error", err)
-		m.llmProvider = &mockLLMProvider{}
-		m.llmProviderType = "Mock (No providers available)"
-	} else {
-		m.llmProvider = provider
-		m.llmProviderType = providerName
+// applyProvider switches every view to the provider created by reloadProvider
+func (m *AppModel) applyProvider(msg providerChangedMsg) (tea.Model, tea.Cmd) {
+	logger := core.GetLogger()
+	if msg.Config == nil {
+		m.errorMsg = "Failed to reload provider configuration"
+		return m, nil
+	}
+
+	if m.dryRun {
+		logger.Info("Dry run active, keeping the dry run provider")
+	} else if m.mockMode {
+		logger.Info("Mock mode active, keeping the mock provider")
+	} else if msg.Error == nil {
+		m.llmProvider = msg.Provider
 	}
 
 	// Update all sub-models with new base model
 	baseModel := BaseModel{
-		repoPath:        m.repoPath,
-		llmProvider:     m.llmProvider,
-		llmProviderType: m.llmProviderType,
-		errorMsg:        m.errorMsg,
+		repoPath:      m.repoPath,
+		llmProvider:   m.llmProvider,
+		errorMsg:      m.errorMsg,
+		advancedMode:  m.advancedMode,
+		hashLength:    m.hashLength,
+		history:       m.history,
+		revisionRange: m.revisionRange,
+		dryRun:        m.dryRun,
+		mockMode:      m.mockMode,
+		layout:        m.layout,
 	}
 
 	// Update all existing models
@@ -287,10 +543,51 @@ func (m *AppModel) reloadProvider(providerID string) (tea.Model, tea.Cmd) {
 	m.formatModel.BaseModel = baseModel
 	m.contentModel.BaseModel = baseModel
 	m.providerModel.BaseModel = baseModel
+	m.fileModel.BaseModel = baseModel
+	m.historyModel.BaseModel = baseModel
+	m.branchModel.BaseModel = baseModel
+	m.outputsModel.BaseModel = baseModel
+	m.analysisModel.BaseModel = baseModel
 	
 	// Update the provider model's configuration to reflect the change
-	m.providerModel.providerConfig = providerConfig
+	m.providerModel.providerConfig = msg.Config
+	m.providerModel.providers = msg.Config.Providers
 
-	logger.Info("Successfully reloaded provider", "provider_name", m.llmProviderType)
+	logger.Info("Successfully reloaded provider", "provider_name", m.providerName())
 	return m, nil
-}
\ No newline at end of file
+}
+
+// openHistoryStore opens the generation history database when COMMITLORE_HISTORY
+// is enabled. Failures are logged and leave history disabled.
+func openHistoryStore() *history.Store {
+	logger := core.GetLogger()
+
+	enabled, _ := strconv.ParseBool(os.Getenv("COMMITLORE_HISTORY"))
+	if !enabled {
+		return nil
+	}
+
+	path, err := history.DefaultPath()
+	if err != nil {
+		logger.Warn("Failed to locate history database, history is disabled", "error", err)
+		return nil
+	}
+
+	store, err := history.Open(path)
+	if err != nil {
+		logger.Warn("Failed to open history database, history is disabled", "path", path, "error", err)
+		return nil
+	}
+
+	logger.Info("Opened history database", "path", path)
+	return store
+}
+
+// Close releases resources held by the application, such as the history database
+func (m *AppModel) Close() {
+	if m.history != nil {
+		if err := m.history.Close(); err != nil {
+			core.GetLogger().Error("Failed to close history database", "error", err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sarkarshuvojit/commitlore/internal/core/config"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
+)
+
+// namedProvider answers every request with a social preview naming itself
+type namedProvider struct {
+	name string
+}
+
+func (p *namedProvider) answer() string {
+	return "description: written by " + p.name + "\nalt: drawn by " + p.name
+}
+
+func (p *namedProvider) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return p.answer(), nil
+}
+
+func (p *namedProvider) GenerateContentWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return p.answer(), nil
+}
+
+func (p *namedProvider) ModelInfo() (string, string) {
+	return p.name, p.name + "-model"
+}
+
+// newTestApp creates an app with every view, using the given provider
+func newTestApp(provider llm.LLMProvider) *AppModel {
+	base := BaseModel{repoPath: "", llmProvider: provider, layout: &layout{}}
+	return &AppModel{
+		BaseModel:     base,
+		splashModel:   NewSplashModel(base),
+		listingModel:  &ListingModel{BaseModel: base},
+		topicModel:    NewTopicModel(base),
+		formatModel:   NewFormatModel(base),
+		contentModel:  NewContentModel(base),
+		providerModel: NewProviderModel(base),
+		fileModel:     NewFileModel(base),
+		historyModel:  &HistoryModel{BaseModel: base},
+		branchModel:   &BranchModel{BaseModel: base},
+		outputsModel:  &OutputsModel{BaseModel: base},
+		analysisModel: NewAnalysisModel(base),
+	}
+}
+
+func TestApplyProviderSwitchesGeneration(t *testing.T) {
+	for _, startup := range []llm.LLMProvider{&namedProvider{name: "old"}, nil} {
+		app := newTestApp(startup)
+		app.applyProvider(providerChangedMsg{
+			ProviderID: "new",
+			Config:     &config.ProviderConfig{},
+			Provider:   &namedProvider{name: "new"},
+		})
+
+		if status := app.topicModel.providerStatus(); status != "new · new-model" {
+			t.Errorf("Expected the topic view to show the new provider, got %q", status)
+		}
+
+		cmd := app.topicModel.startExtraction("system", "user")
+		if cmd == nil {
+			t.Fatalf("Expected topic extraction to start, got error %q", app.topicModel.errorMsg)
+		}
+		if response := llmResponse(cmd); response.Content != (&namedProvider{name: "new"}).answer() {
+			t.Errorf("Expected topic extraction to call the new provider, got %q (%s)", response.Content, response.Error)
+		}
+
+		app.contentModel.generatedContent = "A blog post"
+		cmd = app.contentModel.generateSocialPreview()
+		if cmd == nil {
+			t.Fatalf("Expected the social preview to start, got error %q", app.contentModel.previewError)
+		}
+		if preview := cmd().(socialPreviewMsg); preview.Preview.Description != "written by new" {
+			t.Errorf("Expected the social preview from the new provider, got %+v", preview)
+		}
+	}
+}
+
+func TestGeneratingLabelNamesCallingProvider(t *testing.T) {
+	app := newTestApp(&namedProvider{name: "old"})
+	if cmd := app.topicModel.startExtraction("system", "user"); cmd == nil {
+		t.Fatalf("Expected topic extraction to start, got error %q", app.topicModel.errorMsg)
+	}
+
+	// A provider picked while the request runs does not relabel it
+	app.applyProvider(providerChangedMsg{
+		ProviderID: "new",
+		Config:     &config.ProviderConfig{},
+		Provider:   &namedProvider{name: "new"},
+	})
+	if view := app.topicModel.View(); !strings.Contains(view, "extracting topics with old · old-model") {
+		t.Errorf("Expected the extraction label to name the provider running it, got:\n%s", view)
+	}
+
+	app.topicModel.isExtracting = false
+	if cmd := app.topicModel.startExtraction("system", "user"); cmd == nil {
+		t.Fatalf("Expected topic extraction to start, got error %q", app.topicModel.errorMsg)
+	}
+	if view := app.topicModel.View(); !strings.Contains(view, "extracting topics with new · new-model") {
+		t.Errorf("Expected the next extraction to be labelled with the new provider, got:\n%s", view)
+	}
+}
+
+func TestProviderViewSelectionSwitchesContentGeneration(t *testing.T) {
+	app := newTestApp(&namedProvider{name: "old"})
+	app.currentView = ProviderView
+
+	// The message reloadProvider sends once the provider picked in the
+	// provider view has been created
+	app.Update(providerChangedMsg{
+		ProviderID: "new",
+		Config:     &config.ProviderConfig{ActiveProviderID: "new"},
+		Provider:   &namedProvider{name: "new"},
+	})
+
+	content := app.contentModel
+	content.selectedTopic = "Provider switching"
+	content.selectedFormat = "Blog Post"
+	_, cmd := content.startGeneration()
+	if cmd == nil || content.errorMsg != "" {
+		t.Fatalf("Expected generation to start, got error %q", content.errorMsg)
+	}
+	want := (&namedProvider{name: "new"}).answer()
+	if response := llmResponse(cmd); response.Content != want {
+		t.Errorf("Expected generation to call the new provider, got %q (%s)", response.Content, response.Error)
+	}
+
+	content.generatedContent = "A blog post"
+	refined, ok := content.refineContent("shorter")().(contentRefinedMsg)
+	if !ok || refined.Content != want {
+		t.Errorf("Expected refinement to call the new provider, got %+v", refined)
+	}
+}
+
+// llmResponse runs cmd, and the commands of a batch, until one returns the
+// LLM's response
+func llmResponse(cmd tea.Cmd) llm.LLMResponseMsg {
+	switch msg := cmd().(type) {
+	case llm.LLMResponseMsg:
+		return msg
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			if cmd == nil {
+				continue
+			}
+			if response := llmResponse(cmd); response.Content != "" || response.Error != "" {
+				return response
+			}
+		}
+	}
+	return llm.LLMResponseMsg{}
+}
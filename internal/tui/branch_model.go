@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// BranchModel handles the branch picker, where the user chooses which local
+// branch the commit listing is read from
+type BranchModel struct {
+	BaseModel
+	branches      []string
+	currentBranch string
+	cursor        int
+	viewport      int
+	maxViewport   int
+}
+
+// BranchSelectedMsg is sent when a branch has been chosen for the listing
+type BranchSelectedMsg struct {
+	Ref string
+}
+
+// NewBranchModel creates a new branch model
+func NewBranchModel(base BaseModel) *BranchModel {
+	return &BranchModel{
+		BaseModel:   base,
+		maxViewport: 12,
+	}
+}
+
+func (m *BranchModel) Init() tea.Cmd {
+	m.loadBranches()
+	return nil
+}
+
+func (m *BranchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			if m.cursor < m.viewport {
+				m.viewport = m.cursor
+			}
+		}
+	case "down", "j":
+		if m.cursor < len(m.branches)-1 {
+			m.cursor++
+			if m.cursor >= m.viewport+m.maxViewport {
+				m.viewport = m.cursor - m.maxViewport + 1
+			}
+		}
+	case "enter":
+		if len(m.branches) > 0 {
+			branch := m.branches[m.cursor]
+			core.GetLogger().Info("Selected branch", "branch", branch)
+			return m, func() tea.Msg { return BranchSelectedMsg{Ref: branch} }
+		}
+	case "esc":
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+	return m, nil
+}
+
+// loadBranches lists the local branches, placing the cursor on the checked out one
+func (m *BranchModel) loadBranches() {
+	m.cursor = 0
+	m.viewport = 0
+
+	branches, err := core.ListBranches(m.repoPath)
+	if err != nil {
+		core.GetLogger().Error("Failed to list branches", "error", err)
+		m.errorMsg = fmt.Sprintf("Failed to list branches: %v", err)
+		m.branches = nil
+		return
+	}
+	m.errorMsg = ""
+	m.branches = branches
+	m.currentBranch = core.CurrentBranch(m.repoPath)
+
+	for i, branch := range branches {
+		if branch == m.currentBranch {
+			m.cursor = i
+			if m.cursor >= m.maxViewport {
+				m.viewport = m.cursor - m.maxViewport + 1
+			}
+			break
+		}
+	}
+}
+
+func (m *BranchModel) View() string {
+	header := titleStyle.Render("🌿 Choose Branch")
+	subtitle := subtitleStyle.Render(fmt.Sprintf("%d local branches", len(m.branches)))
+
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	var content string
+	switch {
+	case m.errorMsg != "":
+		content = errorStyle.Render(fmt.Sprintf("⚠ %s", m.errorMsg))
+	case len(m.branches) == 0:
+		content = contentStyle.Width(m.layout.contentWidth()).Render(emptyStyle.Render("📭 No local branches"))
+	default:
+		content = m.renderList()
+	}
+
+	navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
+	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("show commits"))
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", selectHelp, " • ", backHelp, " • ", quitHelp)
+	statusBar := statusBarStyle.Render(helpText)
+
+	main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar)
+	return appStyle.Render(main)
+}
+
+// renderList renders the visible page of branches
+func (m *BranchModel) renderList() string {
+	end := m.viewport + m.maxViewport
+	if end > len(m.branches) {
+		end = len(m.branches)
+	}
+
+	var rows []string
+	for i := m.viewport; i < end; i++ {
+		branch := m.branches[i]
+
+		cursor := "  "
+		branchText := subjectStyle.Render(branch)
+		if i == m.cursor {
+			cursor = "▶ "
+			branchText = selectedSubjectStyle.Render(branch)
+		}
+
+		row := fmt.Sprintf("%s%s", cursor, branchText)
+		if branch == m.currentBranch {
+			row += " " + dateStyle.Render("(checked out)")
+		}
+
+		if i == m.cursor {
+			row = selectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(row)
+		} else {
+			row = commitRowStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	return contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// helpBindings lists the keys for picking a branch
+func (m *BranchModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"enter", "list the branch's commits"},
+		{"esc", "back"},
+	}
+}
@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// changelistMode controls how the selected commits are turned into a changelist
+type changelistMode int
+
+const (
+	// changelistCommits sends each selected commit as its own changeset
+	changelistCommits changelistMode = iota
+	// changelistCompare treats the two selected commits as endpoints of a single diff
+	changelistCompare
+	// changelistOverview samples representative commits from the whole page
+	changelistOverview
+)
+
+// changelistScope controls how much of each changeset is sent. Scopes are
+// ordered from most to least detailed so a retry can step down one level.
+type changelistScope int
+
+const (
+	// changelistScopeFull sends complete diffs
+	changelistScopeFull changelistScope = iota
+	// changelistScopeTruncated cuts each diff down to core.MaxDiffTokens
+	changelistScopeTruncated
+	// changelistScopeMessagesOnly drops diffs, keeping commit messages and files
+	changelistScopeMessagesOnly
+)
+
+// String describes the scope for display
+func (s changelistScope) String() string {
+	switch s {
+	case changelistScopeTruncated:
+		return "truncated diffs"
+	case changelistScopeMessagesOnly:
+		return "commit messages only"
+	default:
+		return "full diffs"
+	}
+}
+
+// scopeRetryHelp renders the key hint for retrying with the next smaller scope
+// after the prompt exceeded the model's context window
+func scopeRetryHelp(current changelistScope) string {
+	notice := helpDescStyle.Render("The selected changes don't fit in the model's context window.")
+	retry := fmt.Sprintf("%s %s", helpKeyStyle.Render("r"), helpDescStyle.Render(fmt.Sprintf("retry with %s", current+1)))
+	return lipgloss.JoinVertical(lipgloss.Left, notice, retry)
+}
+
+// promptTooLargeMessage explains why a prompt that wouldn't fit the model's
+// context window was not sent, see llm.CheckPromptSize
+func promptTooLargeMessage(err error) string {
+	return fmt.Sprintf("Not sent: %v. Deselect some commits, or press D in the listing to send large commits without their diff.", err)
+}
+
+// changelistOptions controls how buildChangelistData renders commits
+type changelistOptions struct {
+	mode       changelistMode
+	scope      changelistScope
+	hashLength int
+	// metadataOnly holds the hashes of commits sent without their diff
+	metadataOnly map[string]bool
+}
+
+// overviewTokenBudget caps how many tokens of sampled changesets are sent
+// when generating an overview
+const overviewTokenBudget = 24000
+
+// buildChangelistData assembles the changelist text sent to the LLM for the
+// selected commits. In compare mode the two selected commits are treated as
+// the endpoints of a single diff rather than as independent changes, and in
+// overview mode the selection is ignored in favour of a sample of all commits.
+func buildChangelistData(repoPath string, commits []core.Commit, selectedCommits map[int]bool, opts changelistOptions) string {
+	logger := core.GetLogger()
+
+	if opts.mode == changelistOverview {
+		return buildOverviewChangelist(repoPath, commits, opts)
+	}
+
+	indices := sortedSelection(commits, selectedCommits)
+
+	if opts.mode == changelistCompare && len(indices) == 2 {
+		// Commits are listed newest first, so the higher index is the older one
+		from := commits[indices[1]]
+		to := commits[indices[0]]
+
+		changeset, err := core.GetChangesBetweenCommits(repoPath, from.Hash, to.Hash)
+		if err == nil {
+			hash := fmt.Sprintf("%s..%s", core.AbbreviateHash(from.Hash, opts.hashLength), core.AbbreviateHash(to.Hash, opts.hashLength))
+			return formatChangesetDetail(hash, changeset, opts.scope)
+		}
+		logger.Error("Failed to get changes between commits, falling back to individual commits",
+			"from", from.Hash, "to", to.Hash, "error", err)
+	}
+
+	indices = prioritizeByType(commits, indices)
+	hasSignal := false
+	for _, index := range indices {
+		if !core.IsNoiseCommitType(commits[index].Type) {
+			hasSignal = true
+			break
+		}
+	}
+
+	var commitDetails []string
+	for _, index := range indices {
+		commit := commits[index]
+
+		// Chores and CI tweaks only get a short diff next to more meaningful commits
+		scope := opts.scope
+		if hasSignal && core.IsNoiseCommitType(commit.Type) && scope < changelistScopeTruncated {
+			scope = changelistScopeTruncated
+		}
+		if opts.metadataOnly[commit.Hash] {
+			scope = changelistScopeMessagesOnly
+		}
+
+		// Get changelist data for this commit
+		changeset, err := core.GetChangesForCommit(repoPath, commit.Hash)
+		if err != nil {
+			logger.Error("Failed to get changeset for commit", "hash", commit.Hash, "error", err)
+			// Fall back to basic commit info
+			detail := fmt.Sprintf("- %s: %s", core.AbbreviateHash(commit.Hash, opts.hashLength), commit.Subject)
+			commitDetails = append(commitDetails, detail)
+			continue
+		}
+
+		commitDetails = append(commitDetails, formatChangesetDetail(core.AbbreviateHash(commit.Hash, opts.hashLength), changeset, scope))
+	}
+
+	return strings.Join(commitDetails, "\n")
+}
+
+// prioritizeByType orders the selected commits so that conventional commit
+// types worth writing about, like feat and fix, come before chores. Commits of
+// equal weight keep their listing order.
+func prioritizeByType(commits []core.Commit, indices []int) []int {
+	prioritized := append([]int(nil), indices...)
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		return core.ConventionalTypeWeight(commits[prioritized[i]].Type) > core.ConventionalTypeWeight(commits[prioritized[j]].Type)
+	})
+	return prioritized
+}
+
+// buildOverviewChangelist samples representative changesets from all the
+// given commits so that they fit in overviewTokenBudget
+func buildOverviewChangelist(repoPath string, commits []core.Commit, opts changelistOptions) string {
+	logger := core.GetLogger()
+
+	changesets := make([]core.Changeset, 0, len(commits))
+	for _, commit := range commits {
+		changeset, err := core.GetChangesForCommit(repoPath, commit.Hash)
+		if err != nil {
+			logger.Error("Failed to get changeset for commit", "hash", commit.Hash, "error", err)
+			continue
+		}
+		changesets = append(changesets, changeset)
+	}
+
+	sample := core.SampleChangesets(changesets, overviewTokenBudget)
+	logger.Info("Sampled changesets for overview", "total", len(changesets), "sampled", len(sample))
+
+	if len(sample) == 0 {
+		return ""
+	}
+
+	commitDetails := []string{fmt.Sprintf(
+		"Overview of %d commits from %s to %s. The following %d commits were sampled as representative of the period:\n",
+		len(changesets),
+		changesets[len(changesets)-1].Date.Format("2006-01-02"),
+		changesets[0].Date.Format("2006-01-02"),
+		len(sample))}
+	for _, changeset := range sample {
+		commitDetails = append(commitDetails, formatChangesetDetail(core.AbbreviateHash(changeset.CommitHash, opts.hashLength), changeset, opts.scope))
+	}
+
+	return strings.Join(commitDetails, "\n")
+}
+
+// sortedSelection returns the selected commit indices in listing order,
+// skipping any that are out of range
+func sortedSelection(commits []core.Commit, selectedCommits map[int]bool) []int {
+	indices := make([]int, 0, len(selectedCommits))
+	for index, selected := range selectedCommits {
+		if selected && index < len(commits) {
+			indices = append(indices, index)
+		}
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// languageSummary describes the languages of the files changed by the commits
+// a changelist covers, e.g. "Languages: Go: 8 files, YAML: 2 files", or
+// returns an empty string when no files are known. An overview covers every
+// commit on the page, other modes the selection.
+func languageSummary(commits []core.Commit, selectedCommits map[int]bool, mode changelistMode) string {
+	var paths []string
+	for index, commit := range commits {
+		if mode == changelistOverview || selectedCommits[index] {
+			paths = append(paths, commit.Paths...)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	return "Languages: " + core.FormatLanguageBreakdown(core.LanguageBreakdown(paths))
+}
+
+// selectionContributors returns the authors and co-authors of the commits a
+// changelist is built from
+func selectionContributors(commits []core.Commit, selectedCommits map[int]bool, mode changelistMode) []core.Contributor {
+	var selected []core.Commit
+	for index, commit := range commits {
+		if mode == changelistOverview || selectedCommits[index] {
+			selected = append(selected, commit)
+		}
+	}
+	return core.Contributors(selected)
+}
+
+// maxListedContributors is how many contributor names the content view shows
+const maxListedContributors = 5
+
+// contributorNames lists the names of contributors, the first few of them
+// followed by how many more there are
+func contributorNames(contributors []core.Contributor) string {
+	var names []string
+	for i, contributor := range contributors {
+		if i == maxListedContributors {
+			names = append(names, fmt.Sprintf("+%d more", len(contributors)-i))
+			break
+		}
+		names = append(names, contributor.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatChangesetDetail renders a changeset with its metadata and, depending
+// on the scope, its diff
+func formatChangesetDetail(hash string, changeset core.Changeset, scope changelistScope) string {
+	diff := changeset.Diff
+	switch scope {
+	case changelistScopeTruncated:
+		if truncated, ok := core.TruncateDiff(diff, core.MaxDiffTokens()); ok {
+			core.GetLogger().Info("Truncated diff to fit the token budget",
+				"hash", hash,
+				"original_tokens", core.EstimateTokenCount(diff),
+				"max_tokens", core.MaxDiffTokens())
+			diff = truncated + "[diff truncated]"
+		}
+	case changelistScopeMessagesOnly:
+		diff = "[diff omitted]"
+	}
+
+	subject := changeset.Subject
+	if changeset.IsMerge {
+		subject += " (merge commit, diff against the first parent)"
+	}
+
+	// Co-authors and issue references parsed from the trailers, so content
+	// can credit people and link the relevant tickets. They are listed in
+	// place of their trailer lines, and other trailers stay in the body.
+	body := changeset.Body
+	var trailers string
+	if core.IncludeTrailers() {
+		body = core.StripTrailers(body, core.ListedTrailers)
+		if coAuthors := changeset.CoAuthors(); len(coAuthors) > 0 {
+			trailers += fmt.Sprintf("Co-authors: %s\n", strings.Join(coAuthors, ", "))
+		}
+		if references := changeset.IssueReferences(); len(references) > 0 {
+			trailers += fmt.Sprintf("References: %s\n", strings.Join(references, ", "))
+		}
+	}
+
+	return fmt.Sprintf(`Commit: %s
+Author: %s
+Date: %s  
+Subject: %s
+Body: %s
+%sFiles Changed: %s
+Diff:
+%s
+
+---`,
+		hash,
+		changeset.Author,
+		changeset.Date.Format("2006-01-02 15:04:05"),
+		subject,
+		body,
+		trailers,
+		strings.Join(changeset.Files, ", "),
+		diff)
+}
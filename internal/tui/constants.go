@@ -16,4 +16,10 @@ const (
 	ContentFormatTwitterThreadDesc = "Engaging tweet series optimized for Twitter's format and audience"
 	ContentFormatLinkedInPostDesc  = "Professional posts for LinkedIn networking and thought leadership"
 	ContentFormatTechnicalDocsDesc = "Comprehensive technical documentation with architecture, APIs, and implementation details"
-)
\ No newline at end of file
+)
+
+// Minimum terminal dimensions required to render the layouts
+const (
+	minTerminalWidth  = 80
+	minTerminalHeight = 24
+)
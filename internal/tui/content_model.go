@@ -2,19 +2,28 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/muesli/reflow/wordwrap"
 	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/history"
 	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 )
 
@@ -22,6 +31,55 @@ import (
 type ContentGeneratedMsg struct {
 	Content string
 	Error   string
+	// SavedPath is set when the message reports a successful save
+	SavedPath string
+}
+
+// socialPreviewMsg is sent when the social preview metadata has been generated
+type socialPreviewMsg struct {
+	Preview llm.SocialPreview
+	Error   string
+}
+
+// contentRefinedMsg is sent when the LLM has refined the generated content
+// based on the user's feedback
+type contentRefinedMsg struct {
+	Format   string
+	Feedback string
+	Content  string
+	Usage    llm.Usage
+	Error    string
+}
+
+// refinement is one applied round of feedback, with the version it replaced
+type refinement struct {
+	Feedback string
+	Previous string
+}
+
+// clipboardCopiedMsg is sent after trying to copy the generated content
+type clipboardCopiedMsg struct {
+	// What was copied, for the status message, when not the whole content
+	What  string
+	Error string
+}
+
+// editorClosedMsg is sent when the editor opened on the saved file exits
+type editorClosedMsg struct {
+	Error error
+}
+
+// clearStatusMsg hides a transient status message
+type clearStatusMsg struct{}
+
+// statusMessageDuration is how long transient status messages stay on screen
+const statusMessageDuration = 2 * time.Second
+
+// clearStatusAfterDelay hides the status message once statusMessageDuration has passed
+func clearStatusAfterDelay() tea.Cmd {
+	return tea.Tick(statusMessageDuration, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
 }
 
 // TickMsg represents a tick for animation
@@ -34,6 +92,18 @@ func doTick() tea.Cmd {
 	})
 }
 
+// generationTimeout bounds how long a generation, refinement or social
+// preview request may run
+const generationTimeout = 2 * time.Minute
+
+// contextFilesTokenBudget caps how many tokens of attached context files are
+// added to the prompt
+const contextFilesTokenBudget = 8000
+
+// contextFilesEnvVar lists context files to attach to every generation,
+// separated like PATH entries
+const contextFilesEnvVar = "COMMITLORE_CONTEXT_FILES"
+
 // ContentModel handles the content creation view
 type ContentModel struct {
 	BaseModel
@@ -43,35 +113,118 @@ type ContentModel struct {
 	generatedContent string
 	isEditingPrompt  bool
 	isGenerating     bool
+	cancelGeneration context.CancelFunc // Cancels the in-flight generation, set while generating
+	generatingWith   string             // Provider and model running the in-flight generation
 	viewport         viewport.Model
+	wrapWidth        int // Width the viewport content was wrapped to
 	showFinalOutput  bool
-	asyncWrapper     *llm.AsyncLLMWrapper
 	commits          []core.Commit
 	selectedCommits  map[int]bool
 	generationStartTime time.Time
 	hourglassFrame   int
+	changelistMode   changelistMode
+	metadataOnly     map[string]bool // Hashes of commits sent without their diff
+	contextFiles     []string
+	contextInput     textinput.Model
+	isAddingContext  bool
+	contextError     string
+	changelistScope  changelistScope
+	canReduceScope   bool
+
+	// Social preview metadata for blog posts, saved as front matter
+	socialPreview       *llm.SocialPreview
+	isGeneratingPreview bool
+	previewError        string
+
+	// Feedback on the generated content, sent with llm.RefinementPrompt when
+	// pressing f. refinements keeps the applied rounds per format, so later
+	// rounds know what was asked before and u can undo the last one.
+	feedbackInput    textinput.Model
+	isGivingFeedback bool
+	isRefining       bool
+	refineError      string
+	refinements      map[string][]refinement
+
+	// tweets is the shown Twitter thread split into tweets while stepping
+	// through them one at a time with t, tweetIndex being the one shown
+	tweets     []string
+	tweetIndex int
+
+	// temperature overrides the provider's default sampling temperature once
+	// it has been nudged with + and -
+	temperature    float64
+	temperatureSet bool
+
+	// lengthTarget overrides the per-format character target given to the
+	// LLM when set with ctrl+l, see llm.DefaultLengthTarget
+	lengthTarget    int
+	lengthInput     textinput.Model
+	isSettingLength bool
+	lengthError     string
+
+	// tone is the audience the content is written for, cycled with tab, see
+	// llm.Tones. It is kept across generations.
+	tone string
+
+	// promptTokens estimates the size of the last prompt, for the history
+	promptTokens int
+	// usage is the token usage the API reported for the last generation
+	usage llm.Usage
+
+	// pathInput asks where to save the content, pre-filled with a default name
+	pathInput      textinput.Model
+	isChoosingPath bool
+	pathError      string
+	// overwritePath is the chosen path when a file already exists there,
+	// waiting for y to overwrite it or n to save under a numbered name
+	overwritePath string
+	// isExportingJSON sends the chosen path to exportJSON instead of saveContent
+	isExportingJSON bool
+	// savedPath is the file the content was last saved to, opened with 'o'
+	savedPath string
+
+	// formats are generated one after another and their results kept by
+	// format. selectedFormat is the one being generated or shown.
+	formats        []string
+	results        map[string]string
+	pendingFormats []string
 }
 
 // NewContentModel creates a new content model
 func NewContentModel(base BaseModel) *ContentModel {
 	vp := viewport.New(80, 20)
 
-	// Create async wrapper with 2 minute timeout
-	var asyncWrapper *llm.AsyncLLMWrapper
-	if base.llmProvider != nil {
-		asyncWrapper = llm.NewAsyncLLMWrapper(base.llmProvider, 2*time.Minute)
-	}
-
 	// Initialize textarea with proper configuration
 	ta := textarea.New()
-	ta.SetWidth(94)    // Match the width of the prompt box
 	ta.SetHeight(8)    // Use most of the available height
 	ta.Placeholder = "Enter your instructions for content generation..."
 	ta.Focus()
 	ta.Prompt = ""
 	ta.ShowLineNumbers = false
 
-	return &ContentModel{
+	ci := textinput.New()
+	ci.Placeholder = "path/to/design-doc.md"
+	ci.Prompt = "📎 "
+
+	pi := textinput.New()
+	pi.Prompt = "💾 "
+
+	li := textinput.New()
+	li.Placeholder = "characters, empty for the format's default"
+	li.Prompt = "📏 "
+
+	fi := textinput.New()
+	fi.Placeholder = "e.g. shorter intro, mention the benchmark numbers"
+	fi.Prompt = "💬 "
+
+	var contextFiles []string
+	for _, path := range filepath.SplitList(os.Getenv(contextFilesEnvVar)) {
+		if path != "" {
+			contextFiles = append(contextFiles, path)
+		}
+	}
+
+	m := &ContentModel{
 		BaseModel:        base,
 		textarea:         ta,
 		generatedContent: "",
@@ -79,7 +232,29 @@ func NewContentModel(base BaseModel) *ContentModel {
 		isGenerating:     false,
 		viewport:         vp,
 		showFinalOutput:  false,
-		asyncWrapper:     asyncWrapper,
+		contextFiles:     contextFiles,
+		contextInput:     ci,
+		pathInput:        pi,
+		lengthInput:      li,
+		feedbackInput:    fi,
+		results:          make(map[string]string),
+		refinements:      make(map[string][]refinement),
+	}
+	m.resizeInputs()
+	return m
+}
+
+// asyncLLM wraps the current provider for a request. It is built per call so
+// a provider or model picked in the provider view is the one that runs.
+func (m *ContentModel) asyncLLM() *llm.AsyncLLMWrapper {
+	return llm.NewAsyncLLMWrapper(m.llmProvider, generationTimeout)
+}
+
+// resizeInputs sizes the prompt textarea and the text inputs to the layout
+func (m *ContentModel) resizeInputs() {
+	m.textarea.SetWidth(m.layout.wrapWidth())
+	for _, input := range []*textinput.Model{&m.contextInput, &m.pathInput, &m.lengthInput, &m.feedbackInput} {
+		input.Width = m.layout.wrapWidth() - 14
 	}
 }
 
@@ -89,6 +264,9 @@ func (m *ContentModel) Init() tea.Cmd {
 
 func (m *ContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resizeInputs()
+		return m, nil
 	case TickMsg:
 		if m.isGenerating {
 			m.hourglassFrame = (m.hourglassFrame + 1) % 4
@@ -96,9 +274,18 @@ func (m *ContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case llm.LLMResponseMsg:
+		// Responses to canceled requests were already dealt with by cancelGenerating
+		if msg.Canceled {
+			return m, nil
+		}
 		m.isGenerating = false
+		if m.cancelGeneration != nil {
+			m.cancelGeneration()
+			m.cancelGeneration = nil
+		}
 		if msg.Error != "" {
 			m.errorMsg = msg.Error
+			m.canReduceScope = msg.ContextLengthExceeded && m.changelistScope < changelistScopeMessagesOnly
 			if !m.showFinalOutput {
 				m.generatedContent = ""
 			}
@@ -112,13 +299,65 @@ func (m *ContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				// This is generated content
 				m.generatedContent = msg.Content
+				m.results[m.selectedFormat] = msg.Content
+				delete(m.refinements, m.selectedFormat)
+				m.usage = msg.Usage
+				if m.selectedFormat == ContentFormatBlogArticle {
+					m.socialPreview = nil
+					m.previewError = ""
+				}
+				m.saveToHistory()
+
+				// Move on to the next queued format
+				if len(m.pendingFormats) > 0 {
+					m.selectedFormat = m.pendingFormats[0]
+					m.pendingFormats = m.pendingFormats[1:]
+					return m.startGeneration()
+				}
+
 				m.showFinalOutput = true
-				// Wrap text to fit viewport width (94 chars to account for padding)
-				wrappedContent := wordwrap.String(msg.Content, 94)
-				m.viewport.SetContent(wrappedContent)
+				m.showVariant(m.selectedFormat)
+				m.checkLengths()
 			}
 		}
 		return m, nil
+	case socialPreviewMsg:
+		m.isGeneratingPreview = false
+		if msg.Error != "" {
+			m.previewError = msg.Error
+		} else {
+			m.previewError = ""
+			preview := msg.Preview
+			m.socialPreview = &preview
+		}
+		return m, nil
+	case contentRefinedMsg:
+		// A regeneration started since the refinement was asked for
+		if !m.isRefining {
+			return m, nil
+		}
+		m.isRefining = false
+		if msg.Error != "" {
+			m.refineError = msg.Error
+			return m, nil
+		}
+		m.refineError = ""
+		m.refinements[msg.Format] = append(m.refinements[msg.Format], refinement{
+			Feedback: msg.Feedback,
+			Previous: m.results[msg.Format],
+		})
+		m.results[msg.Format] = msg.Content
+		m.usage = msg.Usage
+		if msg.Format == ContentFormatBlogArticle {
+			m.socialPreview = nil
+			m.previewError = ""
+		}
+		if msg.Format == m.selectedFormat {
+			m.showVariant(msg.Format)
+			m.checkLengths()
+			m.saveToHistory()
+		}
+		return m, nil
 	case ContentGeneratedMsg:
 		m.isGenerating = false
 		if msg.Error != "" {
@@ -133,33 +372,107 @@ func (m *ContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.showFinalOutput && msg.Content != m.generatedContent {
 				// This is a save success message, show it briefly
 				m.statusMessage = NewSuccessMessage(msg.Content)
+				if msg.SavedPath != "" {
+					m.savedPath = msg.SavedPath
+				}
+				return m, clearStatusAfterDelay()
 			} else {
 				// This is generated content
 				m.generatedContent = msg.Content
+				m.socialPreview = nil
+				m.previewError = ""
 				m.showFinalOutput = true
-				// Wrap text to fit viewport width (94 chars to account for padding)
-				wrappedContent := wordwrap.String(msg.Content, 94)
-				m.viewport.SetContent(wrappedContent)
+				m.setViewportContent(msg.Content)
 			}
 		}
 		return m, nil
+	case clipboardCopiedMsg:
+		if msg.Error != "" {
+			m.statusMessage = NewErrorMessage(msg.Error)
+		} else {
+			if msg.What != "" {
+				m.statusMessage = NewSuccessMessage(fmt.Sprintf("📋 Copied %s to clipboard", msg.What))
+			} else {
+				m.statusMessage = NewSuccessMessage("📋 Copied to clipboard")
+			}
+		}
+		return m, clearStatusAfterDelay()
+	case editorClosedMsg:
+		if msg.Error != nil {
+			core.GetLogger().Error("Editor exited with an error", "path", m.savedPath, "error", msg.Error)
+			m.statusMessage = NewErrorMessage(fmt.Sprintf("Editor failed: %v", msg.Error))
+			return m, clearStatusAfterDelay()
+		}
+		return m, nil
+	case clearStatusMsg:
+		m.statusMessage = nil
+		return m, nil
 	case tea.KeyMsg:
-		// Don't allow input while generating content
+		// Only esc, to cancel, is handled while generating content
 		if m.isGenerating {
+			if msg.String() == "esc" {
+				m.cancelGenerating()
+			}
 			return m, nil
 		}
 
+		// Any key dismisses a status message over the final output and is
+		// then handled as usual
+		if m.showFinalOutput && m.statusMessage != nil {
+			m.statusMessage = nil
+		}
+
+		// The prompt was too large to send: retry with less detail, or go
+		// back to the prompt with esc
+		if m.statusMessage != nil {
+			switch msg.String() {
+			case "r":
+				if m.canReduceScope {
+					m.statusMessage = nil
+					m.changelistScope++
+					core.GetLogger().Info("Retrying content generation with reduced scope", "scope", m.changelistScope.String())
+					return m.startGeneration()
+				}
+			case "esc":
+				m.statusMessage = nil
+				m.canReduceScope = false
+			}
+			return m, nil
+		}
+
+		if m.isAddingContext {
+			return m.updateContextInput(msg)
+		}
+
+		if m.isChoosingPath {
+			return m.updatePathInput(msg)
+		}
+
+		if m.isSettingLength {
+			return m.updateLengthInput(msg)
+		}
+
+		if m.isGivingFeedback {
+			return m.updateFeedbackInput(msg)
+		}
+
+		if m.showFinalOutput && m.tweets != nil {
+			return m.updateTweetStepping(msg)
+		}
+
+		// Retry with less detail after the prompt didn't fit the context window
+		if m.errorMsg != "" && m.canReduceScope && msg.String() == "r" {
+			m.changelistScope++
+			core.GetLogger().Info("Retrying content generation with reduced scope", "scope", m.changelistScope.String())
+			return m.startGeneration()
+		}
+
 		// Handle Enter key specifically - check for plain Enter
 		if msg.Type == tea.KeyEnter {
 			if msg.String() == "enter" {
 				// Plain Enter - trigger content generation
 				if m.isEditingPrompt && !m.showFinalOutput {
-					m.isGenerating = true
-					m.errorMsg = ""
-					m.generationStartTime = time.Now()
-					m.hourglassFrame = 0
-					model, cmd := m.generateContent()
-					return model, tea.Batch(cmd, doTick())
+					return m.generateFormats()
 				}
 			} else {
 				// Shift+Enter, Ctrl+Enter, Alt+Enter - pass to textarea for new line
@@ -173,17 +486,121 @@ func (m *ContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch msg.String() {
-		case "escape":
+		case "esc":
 			if m.showFinalOutput {
 				m.showFinalOutput = false
 			} else {
 				return m, func() tea.Msg { return BackMsg{} }
 			}
+		case "ctrl+o":
+			if m.isEditingPrompt && !m.showFinalOutput {
+				m.isAddingContext = true
+				m.contextError = ""
+				m.contextInput.Reset()
+				return m, m.contextInput.Focus()
+			}
+		case "ctrl+x":
+			if m.isEditingPrompt && !m.showFinalOutput {
+				m.contextFiles = nil
+			}
+		case "ctrl+l":
+			if m.isEditingPrompt && !m.showFinalOutput {
+				m.isSettingLength = true
+				m.lengthError = ""
+				m.lengthInput.Reset()
+				if m.lengthTarget > 0 {
+					m.lengthInput.SetValue(strconv.Itoa(m.lengthTarget))
+				}
+				return m, m.lengthInput.Focus()
+			}
+		case "tab", "shift+tab":
+			if m.isEditingPrompt && !m.showFinalOutput {
+				step := 1
+				if msg.String() == "shift+tab" {
+					step = -1
+				}
+				m.tone = llm.NextTone(m.tone, step)
+			}
+		case "ctrl+up", "ctrl+down", "+", "-":
+			if m.isEditingPrompt && !m.showFinalOutput {
+				step := temperatureStep
+				if msg.String() == "ctrl+down" || msg.String() == "-" {
+					step = -step
+				}
+				// + and - only change the temperature while the instructions
+				// are empty. Otherwise, or without a temperature to change,
+				// they are typed as usual.
+				typed := msg.Type == tea.KeyRunes
+				if (typed && m.textarea.Value() != "") || !m.nudgeTemperature(step) {
+					if !typed {
+						return m, nil
+					}
+					var cmd tea.Cmd
+					m.textarea, cmd = m.textarea.Update(msg)
+					return m, cmd
+				}
+			}
 		default:
 			if m.showFinalOutput {
-				// Handle save command when viewing final output
+				// Ask where to save when viewing final output
 				if (msg.String() == "s" || msg.String() == "S") && m.generatedContent != "" {
-					return m, m.saveContent()
+					m.isChoosingPath = true
+					m.pathError = ""
+					m.pathInput.SetValue(defaultSavePath(m.defaultFilename()))
+					m.pathInput.CursorEnd()
+					return m, m.pathInput.Focus()
+				}
+				// Ask where to export the content and its metadata as JSON
+				if msg.String() == "e" && m.generatedContent != "" {
+					m.isChoosingPath = true
+					m.isExportingJSON = true
+					m.pathError = ""
+					m.pathInput.SetValue(defaultSavePath(strings.TrimSuffix(m.defaultFilename(), outputExtension(m.selectedFormat)) + ".json"))
+					m.pathInput.CursorEnd()
+					return m, m.pathInput.Focus()
+				}
+				// Regenerate the shown format with the same instructions and commit context
+				if msg.String() == "r" {
+					core.GetLogger().Info("Regenerating content", "topic", m.selectedTopic, "format", m.selectedFormat)
+					m.showFinalOutput = false
+					m.pendingFormats = nil
+					return m.startGeneration()
+				}
+				// Switch between the generated formats
+				if msg.String() == "left" || msg.String() == "right" {
+					m.cycleVariant(msg.String() == "right")
+					return m, nil
+				}
+				// Copy the generated content to the system clipboard
+				if msg.String() == "c" && m.generatedContent != "" {
+					return m, copyToClipboard(m.generatedContent, "")
+				}
+				// Step through a thread's tweets to copy them one at a time
+				if msg.String() == "t" && m.selectedFormat == ContentFormatTwitterThread && m.generatedContent != "" {
+					m.tweets = llm.SplitTweets(m.generatedContent)
+					m.tweetIndex = 0
+					m.showTweet()
+					return m, nil
+				}
+				// Open the saved file in the user's editor
+				if msg.String() == "o" {
+					return m, m.openInEditor()
+				}
+				// Ask for feedback to refine the content with
+				if msg.String() == "f" && m.generatedContent != "" && !m.dryRun && !m.isRefining {
+					m.isGivingFeedback = true
+					m.refineError = ""
+					m.feedbackInput.Reset()
+					return m, m.feedbackInput.Focus()
+				}
+				// Undo the last refinement of the shown format
+				if msg.String() == "u" && !m.isRefining {
+					m.undoRefinement()
+					return m, nil
+				}
+				// Generate the social preview description and hero image alt text
+				if msg.String() == "m" && m.selectedFormat == ContentFormatBlogArticle && !m.isGeneratingPreview {
+					return m, m.generateSocialPreview()
 				}
 				// Handle viewport scrolling
 				m.viewport, _ = m.viewport.Update(msg)
@@ -203,6 +620,9 @@ func (m *ContentModel) View() string {
 	if m.errorMsg != "" {
 		errorContent := errorStyle.Render(fmt.Sprintf("⚠ Error: %s", m.errorMsg))
 		helpText := helpDescStyle.Render("Press 'q' or Ctrl+C to quit • 'esc' to go back")
+		if m.canReduceScope {
+			helpText = lipgloss.JoinVertical(lipgloss.Left, scopeRetryHelp(m.changelistScope), helpText)
+		}
 		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, errorContent, helpText))
 	}
 	
@@ -210,14 +630,28 @@ func (m *ContentModel) View() string {
 	if m.statusMessage != nil {
 		statusContent := RenderStatusMessage(m.statusMessage)
 		helpText := helpDescStyle.Render("Press 'q' or Ctrl+C to quit • 'esc' to go back")
+		if m.canReduceScope && !m.showFinalOutput {
+			helpText = lipgloss.JoinVertical(lipgloss.Left, scopeRetryHelp(m.changelistScope), helpText)
+		}
 		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, statusContent, helpText))
 	}
 
 	header := titleStyle.Render("✍️ Content Creation")
-	subtitle := subtitleStyle.Render(fmt.Sprintf("Topic: %s • Format: %s", m.selectedTopic, m.selectedFormat))
+	formatText := m.selectedFormat
+	if len(m.formats) > 1 {
+		formatText = strings.Join(m.formats, ", ")
+		if m.isGenerating {
+			formatText = fmt.Sprintf("%s (%d/%d)", m.selectedFormat, len(m.formats)-len(m.pendingFormats), len(m.formats))
+		}
+	}
+	subtitle := subtitleStyle.Render(fmt.Sprintf("Topic: %s • Format: %s • Tone: %s", m.selectedTopic, formatText, m.toneLabel()))
 
 	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
-	headerWithBg := headerStyle.Width(100).Align(lipgloss.Left).Render(headerContent)
+	if contributors := m.contributors(); len(contributors) > 1 {
+		headerContent = lipgloss.JoinVertical(lipgloss.Left, headerContent,
+			subtitleStyle.Render(fmt.Sprintf("👥 %d contributors: %s", len(contributors), contributorNames(contributors))))
+	}
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
 
 	if m.showFinalOutput {
 		return m.renderFinalOutput(headerWithBg)
@@ -225,28 +659,75 @@ func (m *ContentModel) View() string {
 
 	promptTitle := subjectStyle.Render("📝 Your Instructions")
 	promptBox := commitRowStyle.
-		Width(96).
+		Width(m.layout.boxWidth()).
 		Height(10).
 		Padding(1).
 		Render(m.textarea.View())
 
 	content := lipgloss.JoinVertical(lipgloss.Left, promptTitle, promptBox)
 
+	if len(m.contextFiles) > 0 {
+		attached := helpDescStyle.Render(fmt.Sprintf("📎 Context: %s", strings.Join(m.contextFiles, ", ")))
+		content = lipgloss.JoinVertical(lipgloss.Left, content, attached)
+	}
+
+	if m.isAddingContext {
+		inputBox := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Render(m.contextInput.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, content, inputBox)
+	}
+	if m.contextError != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ %s", m.contextError)))
+	}
+
+	if m.isSettingLength {
+		inputBox := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Render(m.lengthInput.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, content, inputBox)
+	}
+	if m.lengthError != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ %s", m.lengthError)))
+	}
+
 	var helpText string
 	if m.isGenerating {
 		hourglass := m.getHourglassFrame()
 		elapsedTime := m.getElapsedTime()
-		generatingHelp := fmt.Sprintf("%s %s (%s)", helpKeyStyle.Render(hourglass), helpDescStyle.Render("generating content..."), elapsedTime)
-		backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+		generatingHelp := fmt.Sprintf("%s %s (%s)", helpKeyStyle.Render(hourglass), helpDescStyle.Render(fmt.Sprintf("generating content with %s...", m.generatingWith)), m.generationProgress(elapsedTime))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
 		quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
-		helpText = lipgloss.JoinHorizontal(lipgloss.Left, generatingHelp, " • ", backHelp, " • ", quitHelp)
+		helpText = lipgloss.JoinHorizontal(lipgloss.Left, generatingHelp, " • ", cancelHelp, " • ", quitHelp)
+	} else if m.isAddingContext {
+		attachHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("attach file"))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		helpText = lipgloss.JoinHorizontal(lipgloss.Left, attachHelp, " • ", cancelHelp)
+	} else if m.isSettingLength {
+		setHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("set length target"))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		helpText = lipgloss.JoinHorizontal(lipgloss.Left, setHelp, " • ", cancelHelp)
 	} else {
 		typeHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("type"), helpDescStyle.Render("edit prompt"))
 		newlineHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("shift+enter"), helpDescStyle.Render("new line"))
 		generateHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("generate"))
+		attachHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+o"), helpDescStyle.Render("attach context"))
 		backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
-		quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
-		helpText = lipgloss.JoinHorizontal(lipgloss.Left, typeHelp, " • ", newlineHelp, " • ", generateHelp, " • ", backHelp, " • ", quitHelp)
+		quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+c"), helpDescStyle.Render("quit"))
+		helpItems := []string{typeHelp, " • ", newlineHelp, " • ", generateHelp, " • ", attachHelp}
+		if len(m.contextFiles) > 0 {
+			detachHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+x"), helpDescStyle.Render("clear context"))
+			helpItems = append(helpItems, " • ", detachHelp)
+		}
+		lengthHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+l"), helpDescStyle.Render("📏 "+m.lengthLabel()))
+		toneHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("tab"), helpDescStyle.Render("🎯 "+m.toneLabel()))
+		helpItems = append(helpItems, " • ", lengthHelp, " • ", toneHelp)
+		if label := m.temperatureLabel(); label != "" {
+			temperatureHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+↑↓"), helpDescStyle.Render("🌡 "+label))
+			helpItems = append(helpItems, " • ", temperatureHelp)
+		}
+		helpItems = append(helpItems, " • ", backHelp, " • ", quitHelp)
+		helpText = lipgloss.JoinHorizontal(lipgloss.Left, helpItems...)
 	}
 	statusBar := statusBarStyle.Render(helpText)
 
@@ -258,20 +739,408 @@ func (m *ContentModel) View() string {
 func (m *ContentModel) SetContext(topic, format string) {
 	m.selectedTopic = topic
 	m.selectedFormat = format
+	m.formats = []string{format}
+	m.results = make(map[string]string)
+	m.refinements = make(map[string][]refinement)
+	m.pendingFormats = nil
 	m.textarea.SetValue("")
 	m.isEditingPrompt = true
 	m.showFinalOutput = false
 }
 
-// SetContextWithCommits sets the topic, format, and commit data for content generation
-func (m *ContentModel) SetContextWithCommits(topic, format string, commits []core.Commit, selectedCommits map[int]bool) {
+// SetContextWithCommits sets the topic, formats, and commit data for content
+// generation. The formats are generated one after another.
+func (m *ContentModel) SetContextWithCommits(topic string, formats []string, commits []core.Commit, selectedCommits map[int]bool) {
 	m.selectedTopic = topic
-	m.selectedFormat = format
+	m.formats = formats
+	m.selectedFormat = formats[0]
+	m.results = make(map[string]string)
+	m.refinements = make(map[string][]refinement)
+	m.pendingFormats = nil
 	m.textarea.SetValue("")
 	m.isEditingPrompt = true
 	m.showFinalOutput = false
 	m.commits = commits
 	m.selectedCommits = selectedCommits
+	m.changelistScope = changelistScopeFull
+	m.canReduceScope = false
+	m.socialPreview = nil
+	m.previewError = ""
+	m.savedPath = ""
+}
+
+// contributors returns the authors and co-authors of the commits the content
+// is generated from
+func (m *ContentModel) contributors() []core.Contributor {
+	return selectionContributors(m.commits, m.selectedCommits, m.changelistMode)
+}
+
+// repositoryURL returns the web address of the repository's GitHub or GitLab
+// origin, or an empty string when there is none
+func (m *ContentModel) repositoryURL() string {
+	remote, err := core.RepoRemoteURL(m.repoPath)
+	if err != nil {
+		core.GetLogger().Warn("Failed to read the repository's remote", "error", err)
+	}
+	return remote
+}
+
+// updateContextInput handles keys while the user is entering a context file path
+func (m *ContentModel) updateContextInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := strings.TrimSpace(m.contextInput.Value())
+		if path != "" {
+			info, err := os.Stat(path)
+			if err != nil {
+				m.contextError = fmt.Sprintf("Cannot attach %s: %v", path, err)
+				return m, nil
+			}
+			if info.IsDir() {
+				m.contextError = fmt.Sprintf("Cannot attach %s: it is a directory", path)
+				return m, nil
+			}
+			if !containsString(m.contextFiles, path) {
+				m.contextFiles = append(m.contextFiles, path)
+			}
+		}
+		m.contextError = ""
+		m.isAddingContext = false
+		m.contextInput.Blur()
+		return m, nil
+	case "esc":
+		m.contextError = ""
+		m.isAddingContext = false
+		m.contextInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.contextInput, cmd = m.contextInput.Update(msg)
+	return m, cmd
+}
+
+// isCapturingInput reports whether the prompt editor or the context file
+// input is accepting text
+func (m *ContentModel) isCapturingInput() bool {
+	if m.isGenerating || m.errorMsg != "" || m.statusMessage != nil {
+		return false
+	}
+	return m.isAddingContext || m.isChoosingPath || m.isSettingLength || m.isGivingFeedback || (m.isEditingPrompt && !m.showFinalOutput)
+}
+
+// updatePathInput handles keys while the save path is being edited
+func (m *ContentModel) updatePathInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.overwritePath != "" {
+		switch msg.String() {
+		case "y", "Y":
+			path := m.overwritePath
+			m.overwritePath = ""
+			return m, m.writeOutput(path)
+		case "n", "N":
+			path := core.NextFreePath(m.overwritePath)
+			m.overwritePath = ""
+			return m, m.writeOutput(path)
+		case "esc":
+			// Back to editing the path
+			m.overwritePath = ""
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		path, err := resolveOutputPath(m.pathInput.Value())
+		if err != nil {
+			m.pathError = err.Error()
+			return m, nil
+		}
+		m.pathError = ""
+		if _, err := os.Stat(path); err == nil {
+			m.overwritePath = path
+			return m, nil
+		}
+		return m, m.writeOutput(path)
+	case "esc":
+		m.pathError = ""
+		m.isChoosingPath = false
+		m.isExportingJSON = false
+		m.pathInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return m, cmd
+}
+
+// writeOutput closes the path input and saves or exports the content to path
+func (m *ContentModel) writeOutput(path string) tea.Cmd {
+	m.isChoosingPath = false
+	m.pathInput.Blur()
+	if m.isExportingJSON {
+		m.isExportingJSON = false
+		return m.exportJSON(path)
+	}
+	return m.saveContent(path)
+}
+
+// resolveOutputPath expands a leading ~ and makes the save path absolute,
+// relative to the current directory
+func resolveOutputPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("enter a file path to save to")
+	}
+
+	path, err := core.ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	return absPath, nil
+}
+
+// SetChangelistMode sets how the selected commits are turned into a changelist
+func (m *ContentModel) SetChangelistMode(mode changelistMode) {
+	m.changelistMode = mode
+}
+
+// SetMetadataOnly sets the commits, by hash, that are sent without their diff
+func (m *ContentModel) SetMetadataOnly(hashes map[string]bool) {
+	m.metadataOnly = hashes
+}
+
+// updateLengthInput handles keys while the user is entering a length target
+func (m *ContentModel) updateLengthInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		value := strings.TrimSpace(m.lengthInput.Value())
+		target := 0
+		if value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				m.lengthError = fmt.Sprintf("Invalid length target %q: enter a number of characters", value)
+				return m, nil
+			}
+			target = parsed
+		}
+		m.lengthTarget = target
+		m.lengthError = ""
+		m.isSettingLength = false
+		m.lengthInput.Blur()
+		return m, nil
+	case "esc":
+		m.lengthError = ""
+		m.isSettingLength = false
+		m.lengthInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.lengthInput, cmd = m.lengthInput.Update(msg)
+	return m, cmd
+}
+
+// lengthTargetFor returns the character target for format, the user's
+// override if set or else the format's default
+func (m *ContentModel) lengthTargetFor(format string) int {
+	if m.lengthTarget > 0 {
+		return m.lengthTarget
+	}
+	return llm.DefaultLengthTarget(format)
+}
+
+// lengthLabel describes the length target the next generation uses
+func (m *ContentModel) lengthLabel() string {
+	if len(m.formats) > 1 && m.lengthTarget == 0 {
+		return "format defaults"
+	}
+	target := m.lengthTargetFor(m.selectedFormat)
+	switch {
+	case target == 0:
+		return "no length target"
+	case m.selectedFormat == ContentFormatTwitterThread:
+		return fmt.Sprintf("%d chars/tweet", target)
+	default:
+		return fmt.Sprintf("%d chars", target)
+	}
+}
+
+// checkLengths warns when any generated format exceeds its platform's
+// character limit
+func (m *ContentModel) checkLengths() {
+	var warnings []string
+	for _, format := range m.formats {
+		for _, warning := range llm.CheckContentLength(format, m.results[format]) {
+			if len(m.formats) > 1 {
+				warning = fmt.Sprintf("%s: %s", format, warning)
+			}
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(warnings) == 0 {
+		return
+	}
+
+	core.GetLogger().Warn("Generated content exceeds platform limits", "warnings", warnings)
+	m.statusMessage = NewWarningMessage("Content exceeds platform limits:\n" + strings.Join(warnings, "\n"))
+}
+
+// toneLabel describes the tone the next generation uses
+func (m *ContentModel) toneLabel() string {
+	if m.tone == llm.ToneDefault {
+		return "Default"
+	}
+	return m.tone
+}
+
+// temperatureStep is how much ctrl+↑ and ctrl+↓ change the temperature
+const temperatureStep = 0.1
+
+// nudgeTemperature raises or lowers the temperature by step, starting from
+// the provider's default. It reports false for providers without a
+// temperature, which are left alone.
+func (m *ContentModel) nudgeTemperature(step float64) bool {
+	reporter, ok := m.llmProvider.(llm.TemperatureReporter)
+	if !ok {
+		return false
+	}
+	if !m.temperatureSet {
+		m.temperature = reporter.DefaultTemperature()
+		m.temperatureSet = true
+	}
+	m.temperature = llm.ClampTemperature(m.temperature + step)
+	return true
+}
+
+// temperatureLabel describes the temperature the next generation uses, or
+// returns an empty string when the provider has no temperature setting
+func (m *ContentModel) temperatureLabel() string {
+	reporter, ok := m.llmProvider.(llm.TemperatureReporter)
+	if !ok {
+		return ""
+	}
+	if m.temperatureSet {
+		return fmt.Sprintf("%.1f", m.temperature)
+	}
+	return fmt.Sprintf("%.1f (default)", reporter.DefaultTemperature())
+}
+
+// generateFormats generates every chosen format in turn, starting with the first
+func (m *ContentModel) generateFormats() (tea.Model, tea.Cmd) {
+	if len(m.formats) > 0 {
+		m.selectedFormat = m.formats[0]
+		m.pendingFormats = append([]string(nil), m.formats[1:]...)
+	}
+	m.results = make(map[string]string)
+	m.refinements = make(map[string][]refinement)
+	return m.startGeneration()
+}
+
+// showVariant shows the generated content for format in the final output
+func (m *ContentModel) showVariant(format string) {
+	m.selectedFormat = format
+	m.generatedContent = m.results[format]
+	m.tweets = nil
+	m.setViewportContent(m.generatedContent)
+	m.viewport.GotoTop()
+}
+
+// setViewportContent renders content into the viewport, wrapped to the
+// current terminal width
+func (m *ContentModel) setViewportContent(content string) {
+	m.wrapWidth = m.layout.wrapWidth()
+	m.viewport.SetContent(renderContent(content, m.wrapWidth))
+}
+
+// showTweet shows the current tweet of the thread being stepped through
+func (m *ContentModel) showTweet() {
+	if len(m.tweets) == 0 {
+		m.tweets = nil
+		m.setViewportContent(m.generatedContent)
+		return
+	}
+	m.setViewportContent(m.tweets[m.tweetIndex])
+	m.viewport.GotoTop()
+}
+
+// updateTweetStepping handles keys while stepping through a thread's tweets
+func (m *ContentModel) updateTweetStepping(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		if m.tweetIndex > 0 {
+			m.tweetIndex--
+			m.showTweet()
+		}
+	case "right", "l":
+		if m.tweetIndex < len(m.tweets)-1 {
+			m.tweetIndex++
+			m.showTweet()
+		}
+	case "c":
+		return m, copyToClipboard(m.tweets[m.tweetIndex], fmt.Sprintf("tweet %d of %d", m.tweetIndex+1, len(m.tweets)))
+	case "t", "esc":
+		m.showVariant(m.selectedFormat)
+	default:
+		m.viewport, _ = m.viewport.Update(msg)
+	}
+	return m, nil
+}
+
+// cycleVariant shows the next or previous generated format
+func (m *ContentModel) cycleVariant(forward bool) {
+	var generated []string
+	current := 0
+	for _, format := range m.formats {
+		if _, ok := m.results[format]; !ok {
+			continue
+		}
+		if format == m.selectedFormat {
+			current = len(generated)
+		}
+		generated = append(generated, format)
+	}
+	if len(generated) < 2 {
+		return
+	}
+
+	step := len(generated) - 1
+	if forward {
+		step = 1
+	}
+	m.showVariant(generated[(current+step)%len(generated)])
+}
+
+// cancelGenerating abandons the in-flight generation, along with any queued
+// formats, and returns to the prompt editor
+func (m *ContentModel) cancelGenerating() {
+	core.GetLogger().Info("Canceling content generation", "format", m.selectedFormat, "elapsed", m.getElapsedTime())
+	if m.cancelGeneration != nil {
+		m.cancelGeneration()
+		m.cancelGeneration = nil
+	}
+	m.isGenerating = false
+	m.pendingFormats = nil
+	m.showFinalOutput = false
+	m.isEditingPrompt = true
+}
+
+// startGeneration resets the generation state and kicks off content generation
+func (m *ContentModel) startGeneration() (tea.Model, tea.Cmd) {
+	m.isGenerating = true
+	m.errorMsg = ""
+	m.canReduceScope = false
+	m.isRefining = false
+	m.refineError = ""
+	m.generationStartTime = time.Now()
+	m.hourglassFrame = 0
+	model, cmd := m.generateContent()
+	return model, tea.Batch(cmd, doTick())
 }
 
 func (m *ContentModel) generateContent() (tea.Model, tea.Cmd) {
@@ -280,11 +1149,12 @@ func (m *ContentModel) generateContent() (tea.Model, tea.Cmd) {
 		"topic", m.selectedTopic,
 		"format", m.selectedFormat,
 		"prompt_length", len(m.textarea.Value()),
-		"provider", m.llmProviderType)
+		"scope", m.changelistScope.String(),
+		"provider", m.providerName())
 
-	if m.asyncWrapper == nil {
+	if m.llmProvider == nil {
 		m.errorMsg = "LLM provider not configured"
-		logger.Error("LLM provider not configured for content generation", "provider", m.llmProviderType)
+		logger.Error("LLM provider not configured for content generation", "provider", m.providerName())
 		return m, nil
 	}
 
@@ -293,60 +1163,42 @@ func (m *ContentModel) generateContent() (tea.Model, tea.Cmd) {
 	// Create channel for async response
 	responseChan := llm.CreateLLMResponseChannel()
 
-	// Get the appropriate system prompt based on format
-	var systemPrompt string
-	switch m.selectedFormat {
-	case ContentFormatTwitterThread:
-		systemPrompt = llm.TwitterThreadPrompt
-	case ContentFormatBlogArticle:
-		systemPrompt = llm.BlogPostPrompt
-	case ContentFormatLinkedInPost:
-		systemPrompt = llm.LinkedInPostPrompt
-	default:
-		systemPrompt = llm.ContentGenerationPrompt
+	// Build comprehensive changelist data for content generation. An
+	// overview samples all commits, so it doesn't need a selection.
+	var changelistData string
+	if len(m.selectedCommits) > 0 || m.changelistMode == changelistOverview {
+		changelistData = buildChangelistData(m.repoPath, m.commits, m.selectedCommits, changelistOptions{
+			mode:         m.changelistMode,
+			scope:        m.changelistScope,
+			hashLength:   m.hashLength,
+			metadataOnly: m.metadataOnly,
+		})
 	}
 
-	// Build comprehensive changelist data for content generation
-	var changelistData string
-	if m.selectedCommits != nil && len(m.selectedCommits) > 0 {
-		var commitDetails []string
-		for index := range m.selectedCommits {
-			if index < len(m.commits) {
-				commit := m.commits[index]
-				
-				// Get changelist data for this commit
-				changeset, err := core.GetChangesForCommit(m.repoPath, commit.Hash)
-				if err != nil {
-					logger.Error("Failed to get changeset for commit", "hash", commit.Hash, "error", err, "provider", m.llmProviderType)
-					// Fall back to basic commit info
-					detail := fmt.Sprintf("- %s: %s", commit.Hash[:8], commit.Subject)
-					commitDetails = append(commitDetails, detail)
-					continue
-				}
+	// Work by several people is credited to them rather than to a single author
+	var contributors string
+	if list := m.contributors(); len(list) > 1 {
+		contributors = core.FormatContributors(list)
+	}
 
-				// Create detailed commit information with changelist
-				detail := fmt.Sprintf(`Commit: %s
-Author: %s
-Date: %s  
-Subject: %s
-Body: %s
-Files Changed: %s
-Diff:
-%s
-
----`, 
-					commit.Hash[:8], 
-					changeset.Author, 
-					changeset.Date.Format("2006-01-02 15:04:05"),
-					changeset.Subject,
-					changeset.Body,
-					strings.Join(changeset.Files, ", "),
-					changeset.Diff)
-				
-				commitDetails = append(commitDetails, detail)
-			}
-		}
-		changelistData = strings.Join(commitDetails, "\n")
+	// Knowing where the repository is hosted lets the LLM link issues and commits
+	repositoryURL := m.repositoryURL()
+
+	// Get the system prompt for the format, a user template if one exists
+	systemPrompt := llm.GetContentCreationPrompt(m.selectedFormat, llm.PromptData{
+		Topic:         m.selectedTopic,
+		Format:        m.selectedFormat,
+		Changelist:    changelistData,
+		Contributors:  contributors,
+		RepositoryURL: repositoryURL,
+	})
+
+	var extraRequirements string
+	if instruction := llm.LengthInstruction(m.selectedFormat, m.lengthTargetFor(m.selectedFormat)); instruction != "" {
+		extraRequirements = "\n- " + instruction
+	}
+	if instruction := llm.ToneInstruction(m.tone); instruction != "" {
+		extraRequirements += "\n- " + instruction
 	}
 
 	// Use the user's prompt text as the user prompt, including changelist data
@@ -358,19 +1210,51 @@ Please ensure the content is:
 - Properly formatted for the target platform
 - Includes relevant code examples where applicable
 - Optimized for engagement and sharing
-- Instead of being generic, tries to actively target the content based on the actual code changes shown below
+- Instead of being generic, tries to actively target the content based on the actual code changes shown below%s
 
 Additional user instructions: %s
 
 Based on the following commit changesets from the selected commits:
 
-%s`, m.selectedFormat, m.selectedTopic, m.textarea.Value(), changelistData)
+%s`, m.selectedFormat, m.selectedTopic, extraRequirements, m.textarea.Value(), changelistData)
+	if contributors != "" {
+		userPrompt += fmt.Sprintf("\n\nThese commits are the work of several people. Acknowledge them where it suits the format: %s", contributors)
+	}
+	if repositoryURL != "" {
+		userPrompt += fmt.Sprintf("\n\nThe repository is at %s. Where it suits the format, link issue and pull request references such as #123 to %s123 and commits to %s/commit/<hash>, rather than making up links.",
+			repositoryURL, core.IssueURLPrefix(repositoryURL), repositoryURL)
+	}
+
+	if len(m.contextFiles) > 0 {
+		files, err := core.LoadContextFiles(m.contextFiles, contextFilesTokenBudget)
+		if err != nil {
+			logger.Error("Failed to load context files", "files", m.contextFiles, "error", err)
+			m.errorMsg = fmt.Sprintf("Failed to load context files: %v", err)
+			m.isGenerating = false
+			return m, nil
+		}
+		userPrompt += formatContextFiles(files)
+	}
 
 	// Start async LLM call
-	ctx := context.Background()
-	m.asyncWrapper.GenerateContentWithSystemPromptAsync(ctx, systemPrompt, userPrompt, responseChan)
+	m.promptTokens = core.EstimateTokenCount(systemPrompt) + core.EstimateTokenCount(userPrompt)
+	if err := llm.CheckPromptSize(m.providerName(), m.modelName(), m.promptTokens, m.maxTokens()); err != nil {
+		logger.Warn("Not sending a prompt larger than the context window", "prompt_tokens", m.promptTokens, "error", err)
+		m.isGenerating = false
+		m.canReduceScope = m.changelistScope < changelistScopeMessagesOnly
+		m.statusMessage = NewWarningMessage(promptTooLargeMessage(err))
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelGeneration = cancel
+	if m.temperatureSet {
+		ctx = llm.WithTemperature(ctx, m.temperature)
+	}
+	m.generatingWith = m.providerStatus()
+	m.asyncLLM().GenerateContentWithSystemPromptAsync(ctx, systemPrompt, userPrompt, responseChan)
 
-	logger.Info("Started async LLM call for content generation", "provider", m.llmProviderType)
+	logger.Info("Started async LLM call for content generation", "provider", m.providerName(), "temperature", m.temperatureLabel())
 
 	// Return command to wait for response
 	return m, llm.WaitForLLMResponse(responseChan)
@@ -379,24 +1263,144 @@ Based on the following commit changesets from the selected commits:
 // renderFinalOutput renders the final output view with scrollable viewport
 func (m *ContentModel) renderFinalOutput(headerWithBg string) string {
 	contentTitle := subjectStyle.Render("📄 Generated Content")
+	if m.dryRun {
+		contentTitle = subjectStyle.Render("🧪 Assembled Prompt (dry run, nothing was sent)")
+	}
+	if len(m.results) > 1 {
+		contentTitle = lipgloss.JoinVertical(lipgloss.Left, contentTitle, m.renderVariantTabs())
+	}
+	if m.tweets != nil {
+		tweet := m.tweets[m.tweetIndex]
+		length := fmt.Sprintf("%d/%d chars", utf8.RuneCountInString(tweet), llm.TweetCharLimit)
+		if utf8.RuneCountInString(tweet) > llm.TweetCharLimit {
+			length = lipgloss.NewStyle().Foreground(warningColor).Bold(true).Render("⚠ " + length)
+		}
+		contentTitle = subjectStyle.Render(fmt.Sprintf("🐦 Tweet %d of %d", m.tweetIndex+1, len(m.tweets))) + " " + helpDescStyle.Render("• ") + length
+	}
 
-	// Update viewport dimensions
-	m.viewport.Width = 96
-	m.viewport.Height = 15
+	// Follow the terminal size, rewrapping the content when its width changed
+	m.viewport.Width = m.layout.boxWidth()
+	m.viewport.Height = m.layout.viewportHeight(15)
+	if m.wrapWidth != m.layout.wrapWidth() {
+		if m.tweets != nil {
+			m.setViewportContent(m.tweets[m.tweetIndex])
+		} else {
+			m.setViewportContent(m.generatedContent)
+		}
+	}
 
 	viewportContent := commitRowStyle.
-		Width(96).
-		Height(15).
+		Width(m.layout.boxWidth()).
+		Height(m.viewport.Height).
 		Padding(1).
 		Render(m.viewport.View())
 
 	content := lipgloss.JoinVertical(lipgloss.Left, contentTitle, viewportContent)
 
+	if m.isChoosingPath {
+		inputBox := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Render(m.pathInput.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, content, inputBox)
+		if m.pathError != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ %s", m.pathError)))
+		}
+		if m.overwritePath != "" {
+			warning := lipgloss.NewStyle().Foreground(warningColor).Bold(true).Render(fmt.Sprintf("⚠ %s already exists", m.overwritePath))
+			content = lipgloss.JoinVertical(lipgloss.Left, content, warning)
+			overwriteHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("y"), helpDescStyle.Render("overwrite"))
+			renameHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("n"), helpDescStyle.Render("save as "+filepath.Base(core.NextFreePath(m.overwritePath))))
+			editHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("change path"))
+			statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, overwriteHelp, " • ", renameHelp, " • ", editHelp))
+			return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar))
+		}
+		saveHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("save"))
+		if m.isExportingJSON {
+			saveHelp = fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("export JSON"))
+		}
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, saveHelp, " • ", cancelHelp))
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar))
+	}
+
+	if m.isGivingFeedback {
+		inputBox := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Render(m.feedbackInput.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, content, inputBox)
+		refineHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("refine"))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, refineHelp, " • ", cancelHelp))
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar))
+	}
+
+	if m.tweets != nil {
+		stepHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("←→"), helpDescStyle.Render("previous/next tweet"))
+		copyHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("c"), helpDescStyle.Render("copy tweet"))
+		scrollHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓"), helpDescStyle.Render("scroll"))
+		threadHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("t/esc"), helpDescStyle.Render("whole thread"))
+		statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, stepHelp, " • ", copyHelp, " • ", scrollHelp, " • ", threadHelp))
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar))
+	}
+
+	if m.isRefining {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, helpDescStyle.Render("⧖ refining with your feedback..."))
+	} else if m.refineError != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ Refinement failed: %s", m.refineError)))
+	}
+
+	if m.isGeneratingPreview {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, helpDescStyle.Render("⧖ writing social preview..."))
+	} else if m.previewError != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ Social preview failed: %s", m.previewError)))
+	} else if m.socialPreview != nil {
+		description := fmt.Sprintf("%s %s", helpKeyStyle.Render("🔗 og:description"), helpDescStyle.Render(m.socialPreview.Description))
+		altText := fmt.Sprintf("%s %s", helpKeyStyle.Render("🖼  alt"), helpDescStyle.Render(m.socialPreview.AltText))
+		content = lipgloss.JoinVertical(lipgloss.Left, content, description, altText)
+	}
+
 	saveHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("S"), helpDescStyle.Render("save to file"))
+	copyHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("c"), helpDescStyle.Render("copy"))
+	exportHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("e"), helpDescStyle.Render("export JSON"))
 	scrollHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓"), helpDescStyle.Render("scroll"))
 	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
 	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
-	helpText := lipgloss.JoinHorizontal(lipgloss.Left, saveHelp, " • ", scrollHelp, " • ", backHelp, " • ", quitHelp)
+	regenerateHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("r"), helpDescStyle.Render("regenerate"))
+	helpItems := []string{saveHelp, " • ", copyHelp, " • ", exportHelp, " • ", regenerateHelp, " • ", scrollHelp}
+	if !m.dryRun {
+		refineHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("f"), helpDescStyle.Render("refine"))
+		helpItems = append(helpItems, " • ", refineHelp)
+	}
+	if len(m.refinements[m.selectedFormat]) > 0 {
+		undoHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("u"), helpDescStyle.Render("undo refinement"))
+		helpItems = append(helpItems, " • ", undoHelp)
+	}
+	if m.savedPath != "" {
+		openHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("o"), helpDescStyle.Render("open in editor"))
+		helpItems = append(helpItems, " • ", openHelp)
+	}
+	if len(m.results) > 1 {
+		switchHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("←→"), helpDescStyle.Render("switch format"))
+		helpItems = append(helpItems, " • ", switchHelp)
+	}
+	if m.selectedFormat == ContentFormatBlogArticle {
+		previewHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("m"), helpDescStyle.Render("social preview"))
+		helpItems = append(helpItems, " • ", previewHelp)
+	}
+	if m.selectedFormat == ContentFormatTwitterThread {
+		tweetsHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("t"), helpDescStyle.Render("tweet by tweet"))
+		helpItems = append(helpItems, " • ", tweetsHelp)
+	}
+	helpItems = append(helpItems, " • ", backHelp, " • ", quitHelp)
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, helpItems...)
+	details := formatLength(m.generatedContent)
+	if rounds := len(m.refinements[m.selectedFormat]); rounds > 0 {
+		details += fmt.Sprintf(" • refined %d×", rounds)
+	}
+	if usage := m.formatUsage(); usage != "" {
+		details += " • " + usage
+	}
+	helpText = lipgloss.JoinVertical(lipgloss.Left, helpText, positionStyle.Render(details))
 
 	statusBar := statusBarStyle.Render(helpText)
 
@@ -404,27 +1408,85 @@ func (m *ContentModel) renderFinalOutput(headerWithBg string) string {
 	return appStyle.Render(main)
 }
 
-// saveContent saves the generated content to a file
-func (m *ContentModel) saveContent() tea.Cmd {
-	return func() tea.Msg {
-		// Generate filename based on topic and format
-		topic := m.sanitizeFilename(m.selectedTopic)
-		format := m.sanitizeFilename(m.selectedFormat)
-		filename := fmt.Sprintf("%s_%s.txt", topic, format)
+// renderVariantTabs renders the generated formats as tabs, highlighting the one shown
+func (m *ContentModel) renderVariantTabs() string {
+	var tabs []string
+	for _, format := range m.formats {
+		if _, ok := m.results[format]; !ok {
+			continue
+		}
+		if format == m.selectedFormat {
+			tabs = append(tabs, helpKeyStyle.Render("["+format+"]"))
+		} else {
+			tabs = append(tabs, helpDescStyle.Render(" "+format+" "))
+		}
+	}
+	return strings.Join(tabs, " ")
+}
 
-		// Get current directory
-		cwd, err := os.Getwd()
-		if err != nil {
+// formatUsage describes the token usage of the last generation and its rough
+// cost, or returns an empty string when the provider didn't report usage
+func (m *ContentModel) formatUsage() string {
+	if m.usage.InputTokens == 0 && m.usage.OutputTokens == 0 {
+		return ""
+	}
+
+	usage := fmt.Sprintf("Used %d input / %d output tokens", m.usage.InputTokens, m.usage.OutputTokens)
+	if cost, ok := llm.EstimateCost(m.modelName(), m.usage); ok {
+		usage += fmt.Sprintf(" (~$%.4f)", cost)
+	}
+	return usage
+}
+
+// defaultFilename generates a filename based on topic and format
+func (m *ContentModel) defaultFilename() string {
+	topic := sanitizeFilename(m.selectedTopic)
+	format := sanitizeFilename(m.selectedFormat)
+	return fmt.Sprintf("%s_%s%s", topic, format, outputExtension(m.selectedFormat))
+}
+
+// defaultSavePath is the path the save prompt offers for filename: a bare
+// filename, saved to the working directory, unless COMMITLORE_OUTPUT_DIR
+// points elsewhere
+func defaultSavePath(filename string) string {
+	if os.Getenv(core.OutputDirEnvVar) == "" {
+		return filename
+	}
+	return filepath.Join(outputDir(), filename)
+}
+
+// saveContent saves the generated content to fullPath, creating its parent
+// directories if needed
+func (m *ContentModel) saveContent(fullPath string) tea.Cmd {
+	return func() tea.Msg {
+		dir := filepath.Dir(fullPath)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return ContentGeneratedMsg{
+					Error: fmt.Sprintf("Cannot create %s: permission denied", dir),
+				}
+			}
 			return ContentGeneratedMsg{
-				Error: fmt.Sprintf("Failed to get current directory: %v", err),
+				Error: fmt.Sprintf("Failed to create directory %s: %v", dir, err),
 			}
 		}
 
-		// Create full path
-		fullPath := filepath.Join(cwd, filename)
+		output := m.generatedContent
+		if m.selectedFormat == ContentFormatBlogArticle {
+			output = formatFrontMatter(m.selectedTopic, time.Now(), m.socialPreview) + output
+		}
+
+		// Only reached for an existing file once the overwrite was confirmed
+		_, statErr := os.Stat(fullPath)
+		overwrote := statErr == nil
 
 		// Write content to file
-		err = os.WriteFile(fullPath, []byte(m.generatedContent), 0644)
+		err := os.WriteFile(fullPath, []byte(output), 0644)
+		if errors.Is(err, fs.ErrPermission) {
+			return ContentGeneratedMsg{
+				Error: fmt.Sprintf("Cannot save to %s: %s is not writable", fullPath, dir),
+			}
+		}
 		if err != nil {
 			return ContentGeneratedMsg{
 				Error: fmt.Sprintf("Failed to save file: %v", err),
@@ -432,15 +1494,397 @@ func (m *ContentModel) saveContent() tea.Cmd {
 		}
 
 		// Return success message (we'll handle this in the Update method)
+		message := fmt.Sprintf("✅ Content saved to: %s", fullPath)
+		if overwrote {
+			message = fmt.Sprintf("✅ Overwrote %s", fullPath)
+		}
 		return ContentGeneratedMsg{
-			Content: fmt.Sprintf("✅ Content saved to: %s", fullPath),
-			Error:   "",
+			Content:   message,
+			Error:     "",
+			SavedPath: fullPath,
+		}
+	}
+}
+
+// copyToClipboard copies content to the system clipboard. what describes it
+// in the status message, empty for the whole generated content.
+func copyToClipboard(content, what string) tea.Cmd {
+	return func() tea.Msg {
+		if clipboard.Unsupported {
+			return clipboardCopiedMsg{Error: "No clipboard available (install xclip, xsel or wl-clipboard, or over SSH use S to save to a file)"}
+		}
+		if err := clipboard.WriteAll(content); err != nil {
+			core.GetLogger().Error("Failed to copy content to clipboard", "error", err)
+			return clipboardCopiedMsg{Error: fmt.Sprintf("Failed to copy to clipboard: %v", err)}
+		}
+		return clipboardCopiedMsg{What: what}
+	}
+}
+
+// defaultEditors are tried, in order, when neither $EDITOR nor $VISUAL is set
+var defaultEditors = []string{"vi", "nano"}
+
+// editorCommand returns the command line of the user's editor: $EDITOR,
+// then $VISUAL, then the first of defaultEditors found on the PATH
+func editorCommand() []string {
+	for _, env := range []string{"EDITOR", "VISUAL"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	editors := defaultEditors
+	if runtime.GOOS == "windows" {
+		editors = []string{"notepad"}
+	}
+	for _, editor := range editors {
+		if _, err := exec.LookPath(editor); err == nil {
+			return []string{editor}
+		}
+	}
+	return nil
+}
+
+// openInEditor suspends the TUI and opens the saved file in the user's
+// editor. Without an editor the full path is shown so it can be copied.
+func (m *ContentModel) openInEditor() tea.Cmd {
+	if m.savedPath == "" {
+		m.statusMessage = NewInfoMessage("Save the content with S first, then press o to open it")
+		return clearStatusAfterDelay()
+	}
+
+	editor := editorCommand()
+	if editor == nil {
+		m.statusMessage = NewInfoMessage(fmt.Sprintf("No editor found, set $EDITOR. The content is saved at:\n%s", m.savedPath))
+		return nil
+	}
+
+	core.GetLogger().Info("Opening saved content in editor", "editor", editor[0], "path", m.savedPath)
+	cmd := exec.Command(editor[0], append(editor[1:], m.savedPath)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorClosedMsg{Error: err}
+	})
+}
+
+// selectedHashes returns the full hashes of the selected commits in listing order
+func (m *ContentModel) selectedHashes() []string {
+	var commitHashes []string
+	for _, index := range sortedSelection(m.commits, m.selectedCommits) {
+		commitHashes = append(commitHashes, m.commits[index].Hash)
+	}
+	return commitHashes
+}
+
+// exportJSON writes the generated content with its topic, format, provider
+// and commits to fullPath as JSON
+func (m *ContentModel) exportJSON(fullPath string) tea.Cmd {
+	export := core.ContentExport{
+		Topic:       m.selectedTopic,
+		Format:      m.selectedFormat,
+		Provider:    m.providerName(),
+		Model:       m.modelName(),
+		GeneratedAt: time.Now(),
+		Commits:     m.selectedHashes(),
+		Content:     m.generatedContent,
+	}
+	return func() tea.Msg {
+		_, statErr := os.Stat(fullPath)
+		if err := core.ExportJSON(fullPath, export); err != nil {
+			core.GetLogger().Error("Failed to export content", "path", fullPath, "error", err)
+			return ContentGeneratedMsg{Error: fmt.Sprintf("Failed to export JSON: %v", err)}
+		}
+		if statErr == nil {
+			return ContentGeneratedMsg{Content: fmt.Sprintf("✅ Overwrote %s with the JSON export", fullPath)}
 		}
+		return ContentGeneratedMsg{Content: fmt.Sprintf("✅ Exported JSON to: %s", fullPath)}
+	}
+}
+
+// saveToHistory records the generated content in the history database, if enabled
+func (m *ContentModel) saveToHistory() {
+	if m.history == nil || m.dryRun {
+		return
+	}
+	logger := core.GetLogger()
+
+	promptTokens := m.promptTokens
+	completionTokens := core.EstimateTokenCount(m.generatedContent)
+	if m.usage.InputTokens > 0 || m.usage.OutputTokens > 0 {
+		promptTokens = m.usage.InputTokens
+		completionTokens = m.usage.OutputTokens
+	}
+
+	id, err := m.history.Save(history.Generation{
+		RepoPath:         m.repoPath,
+		Topic:            m.selectedTopic,
+		Format:           m.selectedFormat,
+		Provider:         m.providerName(),
+		Model:            m.modelName(),
+		CommitHashes:     m.selectedHashes(),
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Content:          m.generatedContent,
+	})
+	if err != nil {
+		logger.Error("Failed to save generation to history", "error", err)
+		return
 	}
+	logger.Info("Saved generation to history", "id", id)
+}
+
+// generateSocialPreview asks the LLM for a social preview description and
+// hero image alt text for the generated blog post
+func (m *ContentModel) generateSocialPreview() tea.Cmd {
+	logger := core.GetLogger()
+
+	if m.llmProvider == nil {
+		m.previewError = "LLM provider not configured"
+		return nil
+	}
+
+	m.isGeneratingPreview = true
+	m.previewError = ""
+
+	userPrompt := fmt.Sprintf("Topic: %s\n\nBlog post:\n\n%s", m.selectedTopic, m.generatedContent)
+
+	responseChan := llm.CreateLLMResponseChannel()
+	m.asyncLLM().GenerateContentWithSystemPromptAsync(context.Background(), llm.SocialPreviewPrompt, userPrompt, responseChan)
+
+	logger.Info("Started async LLM call for social preview", "provider", m.providerName())
+
+	wait := llm.WaitForLLMResponse(responseChan)
+	return func() tea.Msg {
+		response := wait().(llm.LLMResponseMsg)
+		if response.Error != "" {
+			return socialPreviewMsg{Error: response.Error}
+		}
+
+		preview, err := llm.ParseSocialPreview(response.Content)
+		if err != nil {
+			logger.Error("Failed to parse social preview", "error", err, "response", core.LogExcerpt(response.Content))
+			return socialPreviewMsg{Error: err.Error()}
+		}
+		return socialPreviewMsg{Preview: preview}
+	}
+}
+
+// updateFeedbackInput handles keys while the user is typing feedback on the
+// generated content
+func (m *ContentModel) updateFeedbackInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		feedback := strings.TrimSpace(m.feedbackInput.Value())
+		m.isGivingFeedback = false
+		m.feedbackInput.Blur()
+		if feedback == "" {
+			return m, nil
+		}
+		return m, m.refineContent(feedback)
+	case "esc":
+		m.isGivingFeedback = false
+		m.feedbackInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.feedbackInput, cmd = m.feedbackInput.Update(msg)
+	return m, cmd
+}
+
+// refineContent asks the LLM to rework the shown content according to
+// feedback, replacing it once the refined version arrives
+func (m *ContentModel) refineContent(feedback string) tea.Cmd {
+	logger := core.GetLogger()
+
+	if m.llmProvider == nil {
+		m.refineError = "LLM provider not configured"
+		return nil
+	}
+
+	m.isRefining = true
+	m.refineError = ""
+	format := m.selectedFormat
+
+	responseChan := llm.CreateLLMResponseChannel()
+	m.asyncLLM().GenerateContentWithSystemPromptAsync(context.Background(), llm.RefinementPrompt, m.refinementUserPrompt(feedback), responseChan)
+
+	logger.Info("Started async LLM call for refinement", "format", format, "round", len(m.refinements[format])+1, "provider", m.providerName())
+
+	wait := llm.WaitForLLMResponse(responseChan)
+	return func() tea.Msg {
+		response := wait().(llm.LLMResponseMsg)
+		if response.Error != "" {
+			return contentRefinedMsg{Format: format, Feedback: feedback, Error: response.Error}
+		}
+		content := strings.TrimSpace(response.Content)
+		if content == "" {
+			return contentRefinedMsg{Format: format, Feedback: feedback, Error: "the LLM returned no content"}
+		}
+		return contentRefinedMsg{Format: format, Feedback: feedback, Content: content, Usage: response.Usage}
+	}
+}
+
+// refinementUserPrompt builds the user prompt for a refinement round: the
+// feedback, the feedback of earlier rounds and the current content.
+// RefinementPrompt asks for recommendations, so the prompt asks for the
+// rewritten content alone, which replaces the shown content as is.
+func (m *ContentModel) refinementUserPrompt(feedback string) string {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Refine this %s about \"%s\" according to the feedback below.\n\n", m.selectedFormat, m.selectedTopic)
+	fmt.Fprintf(&prompt, "Feedback: %s\n", feedback)
+
+	if earlier := m.refinements[m.selectedFormat]; len(earlier) > 0 {
+		prompt.WriteString("\nEarlier feedback, already applied, keep it in place:\n")
+		for _, round := range earlier {
+			fmt.Fprintf(&prompt, "- %s\n", round.Feedback)
+		}
+	}
+
+	prompt.WriteString("\nRespond with the complete refined content only, ready to publish in the same format. Do not include recommendations, rationale or any commentary.\n\n")
+	fmt.Fprintf(&prompt, "Content:\n\n%s", m.generatedContent)
+	return prompt.String()
+}
+
+// undoRefinement restores the version the last refinement of the shown
+// format replaced
+func (m *ContentModel) undoRefinement() {
+	rounds := m.refinements[m.selectedFormat]
+	if len(rounds) == 0 {
+		return
+	}
+	last := rounds[len(rounds)-1]
+	m.refinements[m.selectedFormat] = rounds[:len(rounds)-1]
+	m.results[m.selectedFormat] = last.Previous
+	m.refineError = ""
+	if m.selectedFormat == ContentFormatBlogArticle {
+		m.socialPreview = nil
+		m.previewError = ""
+	}
+	m.showVariant(m.selectedFormat)
+	m.checkLengths()
+	core.GetLogger().Info("Undid refinement", "format", m.selectedFormat, "feedback", last.Feedback)
+}
+
+// outputExtension returns the file extension for saved content, Markdown for
+// formats that are written as Markdown documents
+func outputExtension(format string) string {
+	switch format {
+	case ContentFormatBlogArticle, ContentFormatTechnicalDocs:
+		return ".md"
+	default:
+		return ".txt"
+	}
+}
+
+// formatFrontMatter renders YAML front matter for a blog post with its title,
+// date, tags derived from the title and, if generated, the social preview
+// metadata
+func formatFrontMatter(title string, date time.Time, preview *llm.SocialPreview) string {
+	var quotedTags []string
+	for _, tag := range topicTags(title) {
+		quotedTags = append(quotedTags, yamlQuote(tag))
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", yamlQuote(title))
+	fmt.Fprintf(&b, "date: %s\n", date.Format("2006-01-02"))
+	fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(quotedTags, ", "))
+	if preview != nil {
+		fmt.Fprintf(&b, "description: %s\n", yamlQuote(preview.Description))
+		fmt.Fprintf(&b, "image_alt: %s\n", yamlQuote(preview.AltText))
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// yamlQuote renders text as a YAML double-quoted scalar, escaping
+// backslashes, quotes and control characters with YAML escape sequences
+func yamlQuote(text string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range text {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\x%02X`, r)
+		case r == 0x85 || r == 0x2028 || r == 0x2029:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// maxTopicTags caps how many tags are derived from a topic
+const maxTopicTags = 5
+
+// tagStopWords are common words that make poor tags
+var tagStopWords = map[string]bool{
+	"and": true, "the": true, "for": true, "with": true, "from": true,
+	"into": true, "via": true, "how": true, "why": true, "what": true,
+	"your": true, "our": true, "its": true, "using": true, "about": true,
+}
+
+// topicTags derives lowercase tags from the words of a topic, skipping short
+// and common words
+func topicTags(topic string) []string {
+	words := strings.FieldsFunc(strings.ToLower(topic), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '.'
+	})
+
+	var tags []string
+	for _, word := range words {
+		word = strings.Trim(word, "-.")
+		if len(word) < 3 || tagStopWords[word] || containsString(tags, word) {
+			continue
+		}
+		tags = append(tags, word)
+		if len(tags) == maxTopicTags {
+			break
+		}
+	}
+	return tags
+}
+
+// formatContextFiles renders attached context files as a prompt section
+func formatContextFiles(files []core.ContextFile) string {
+	var sb strings.Builder
+	sb.WriteString("\n\nAdditional context documents provided by the user. Use them to explain why the changes were made:\n")
+	for _, file := range files {
+		sb.WriteString(fmt.Sprintf("\n=== %s ===\n", file.Path))
+		sb.WriteString(file.Content)
+		if file.Truncated {
+			sb.WriteString("\n[truncated to fit the token budget]")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // sanitizeFilename removes invalid characters from filename
-func (m *ContentModel) sanitizeFilename(filename string) string {
+func sanitizeFilename(filename string) string {
 	// Replace spaces with underscores
 	filename = strings.ReplaceAll(filename, " ", "_")
 
@@ -454,10 +1898,12 @@ func (m *ContentModel) sanitizeFilename(filename string) string {
 	return filename
 }
 
+// hourglassFrames are the frames of the hourglass animation shown while waiting
+var hourglassFrames = []string{"⧖", "⧗", "⧑", "⧒"}
+
 // getHourglassFrame returns the current frame of the hourglass animation
 func (m *ContentModel) getHourglassFrame() string {
-	frames := []string{"⧖", "⧗", "⧑", "⧒"}
-	return frames[m.hourglassFrame]
+	return hourglassFrames[m.hourglassFrame]
 }
 
 // getElapsedTime returns human-readable elapsed time
@@ -465,8 +1911,29 @@ func (m *ContentModel) getElapsedTime() string {
 	if m.generationStartTime.IsZero() {
 		return ""
 	}
-	elapsed := time.Since(m.generationStartTime)
-	
+	return formatDuration(time.Since(m.generationStartTime))
+}
+
+// generationProgress describes how far generation has got: the elapsed time
+// against the timeout and, once any content has arrived, its length
+func (m *ContentModel) generationProgress(elapsedTime string) string {
+	progress := elapsedTime
+	if m.llmProvider != nil {
+		progress = fmt.Sprintf("%s of %s timeout", elapsedTime, formatDuration(generationTimeout))
+	}
+	if m.generatedContent != "" {
+		progress += " • " + formatLength(m.generatedContent)
+	}
+	return progress
+}
+
+// formatLength describes the length of generated content in words and characters
+func formatLength(content string) string {
+	return fmt.Sprintf("%d words, %d chars", len(strings.Fields(content)), utf8.RuneCountInString(content))
+}
+
+// formatDuration renders a duration as e.g. 850ms, 12s, 2m or 2m 5s
+func formatDuration(elapsed time.Duration) string {
 	if elapsed < time.Second {
 		return fmt.Sprintf("%.0fms", float64(elapsed.Nanoseconds())/1e6)
 	} else if elapsed < time.Minute {
@@ -474,6 +1941,47 @@ func (m *ContentModel) getElapsedTime() string {
 	} else {
 		minutes := int(elapsed.Minutes())
 		seconds := int(elapsed.Seconds()) % 60
+		if seconds == 0 {
+			return fmt.Sprintf("%dm", minutes)
+		}
 		return fmt.Sprintf("%dm %ds", minutes, seconds)
 	}
 }
+
+// helpBindings lists the keys for the prompt editor, or, once content has
+// been generated, the keys for the output and the current format
+func (m *ContentModel) helpBindings() []helpBinding {
+	if m.showFinalOutput {
+		bindings := []helpBinding{
+			{"↑↓", "scroll"},
+			{"s", "save to file"},
+			{"c", "copy to clipboard"},
+			{"e", "export as JSON with its metadata"},
+			{"o", "open the saved file in $EDITOR"},
+			{"r", "regenerate"},
+			{"f", "refine with feedback"},
+			{"u", "undo the last refinement"},
+		}
+		if len(m.results) > 1 {
+			bindings = append(bindings, helpBinding{"← →", "switch format"})
+		}
+		if m.selectedFormat == ContentFormatBlogArticle {
+			bindings = append(bindings, helpBinding{"m", "generate social preview"})
+		}
+		if m.selectedFormat == ContentFormatTwitterThread {
+			bindings = append(bindings, helpBinding{"t", "step through the tweets, ← → to move and c to copy one"})
+		}
+		return append(bindings, helpBinding{"esc", "back to the prompt"})
+	}
+	return []helpBinding{
+		{"enter", "generate content"},
+		{"shift+enter", "new line in the prompt"},
+		{"ctrl+o", "attach a context file"},
+		{"ctrl+x", "remove context files"},
+		{"ctrl+l", "set a length target in characters"},
+		{"tab / shift+tab", "cycle the tone and audience"},
+		{"ctrl+↑ / ctrl+↓", "raise or lower the temperature, or + / - before typing instructions"},
+		{"r", "retry with a smaller diff after an error"},
+		{"esc", "cancel generation, or go back to formats"},
+	}
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
+)
+
+func TestYamlQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Plain", "Retry logic", `"Retry logic"`},
+		{"Quotes and backslashes", `Say "hi" to C:\tmp`, `"Say \"hi\" to C:\\tmp"`},
+		{"Line breaks", "one\ntwo\r\tthree", `"one\ntwo\r\tthree"`},
+		{"Control characters", "bell\a and del\x7f", `"bell\x07 and del\x7F"`},
+		{"Unicode line separators", "a\u2028b\u0085c", `"a\u2028b\u0085c"`},
+		{"Non-ASCII", "Café ☕ 日本", `"Café ☕ 日本"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := yamlQuote(tt.text); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatFrontMatter(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	preview := &llm.SocialPreview{Description: `Why we "retry"`, AltText: "A graph\nof retries"}
+
+	got := formatFrontMatter("Retrying flaky uploads", date, preview)
+	for _, want := range []string{
+		"---\n",
+		`title: "Retrying flaky uploads"` + "\n",
+		"date: 2024-03-01\n",
+		`description: "Why we \"retry\""` + "\n",
+		`image_alt: "A graph\nof retries"` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected front matter to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+// temperatureProvider is a namedProvider that samples with a temperature
+type temperatureProvider struct {
+	namedProvider
+}
+
+func (p *temperatureProvider) DefaultTemperature() float64 {
+	return 0.5
+}
+
+func TestTemperatureKeys(t *testing.T) {
+	m := NewContentModel(BaseModel{llmProvider: &temperatureProvider{namedProvider{name: "hot"}}, layout: &layout{}})
+	m.isEditingPrompt = true
+	m.textarea.Focus()
+	press := func(msg tea.KeyMsg) {
+		model, _ := m.Update(msg)
+		m = model.(*ContentModel)
+	}
+
+	// + and - change the temperature before any instructions are typed
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if label := m.temperatureLabel(); label != "0.6" {
+		t.Errorf("Expected + to raise the temperature to 0.6, got %q", label)
+	}
+
+	// Once there are instructions they are typed
+	for _, key := range "a+b-c" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+	}
+	if value := m.textarea.Value(); value != "a+b-c" {
+		t.Errorf("Expected + and - to be typed, got %q", value)
+	}
+	if label := m.temperatureLabel(); label != "0.6" {
+		t.Errorf("Expected typing to leave the temperature at 0.6, got %q", label)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlDown})
+	press(tea.KeyMsg{Type: tea.KeyCtrlDown})
+	if label := m.temperatureLabel(); label != "0.4" {
+		t.Errorf("Expected ctrl+down to lower the temperature to 0.4, got %q", label)
+	}
+	if value := m.textarea.Value(); value != "a+b-c" {
+		t.Errorf("Expected ctrl+down to leave the instructions alone, got %q", value)
+	}
+}
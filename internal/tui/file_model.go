@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// FileModel handles the file history view, where the user picks a path
+// and then selects from the commits that touched it
+type FileModel struct {
+	BaseModel
+	input  textinput.Model
+	follow bool // Follow the path's history past renames, toggled with tab
+}
+
+// FileSelectedMsg is sent when a path has been chosen for the file history
+type FileSelectedMsg struct {
+	Path string
+	// Follow lists the path's history past renames, see core.CommitLogOptions
+	Follow bool
+}
+
+// NewFileModel creates a new file model
+func NewFileModel(base BaseModel) *FileModel {
+	ti := textinput.New()
+	ti.Placeholder = "path/to/file/or/directory"
+	ti.Prompt = "📄 "
+	ti.Width = base.layout.wrapWidth() - 14
+
+	return &FileModel{
+		BaseModel: base,
+		input:     ti,
+		follow:    true,
+	}
+}
+
+func (m *FileModel) Init() tea.Cmd {
+	m.errorMsg = ""
+	return m.input.Focus()
+}
+
+func (m *FileModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.input.Width = m.layout.wrapWidth() - 14
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			return m, m.selectPath()
+		case "tab":
+			m.follow = !m.follow
+			return m, nil
+		case "esc":
+			m.input.Blur()
+			return m, func() tea.Msg { return BackMsg{} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// selectPath validates the entered path and emits a FileSelectedMsg
+func (m *FileModel) selectPath() tea.Cmd {
+	logger := core.GetLogger()
+
+	path, err := core.RelativeRepoPath(m.repoPath, m.input.Value())
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+
+	if path != "" {
+		page, err := core.GetCommitLogsWithOptions(m.repoPath, core.CommitLogOptions{Path: path, Follow: m.follow}, 1, 1)
+		if err != nil {
+			logger.Error("Failed to load file history", "path", path, "error", err)
+			m.errorMsg = fmt.Sprintf("Failed to load history for %s: %v", path, err)
+			return nil
+		}
+		if len(page.Commits) == 0 {
+			m.errorMsg = fmt.Sprintf("No commits found touching %s", path)
+			return nil
+		}
+	}
+
+	logger.Info("Selected file history path", "path", path, "follow", m.follow)
+	m.errorMsg = ""
+	m.input.Blur()
+	follow := m.follow
+	return func() tea.Msg { return FileSelectedMsg{Path: path, Follow: follow} }
+}
+
+// isCapturingInput reports whether the path input has focus
+func (m *FileModel) isCapturingInput() bool {
+	return m.input.Focused()
+}
+
+func (m *FileModel) View() string {
+	header := titleStyle.Render("📂 File History")
+	subtitle := subtitleStyle.Render("Enter a file or directory to see every commit that touched it")
+
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	inputBox := commitRowStyle.
+		Width(m.layout.boxWidth()).
+		Padding(1).
+		Render(m.input.View())
+
+	followText := "☐ Follow renames (a single file's history before it was renamed)"
+	if m.follow {
+		followText = "☑ Follow renames (a single file's history before it was renamed)"
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, inputBox, helpDescStyle.Render(followText))
+	if m.errorMsg != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, errorStyle.Render(fmt.Sprintf("⚠ %s", m.errorMsg)))
+	}
+
+	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("show commits (empty for all)"))
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+c"), helpDescStyle.Render("quit"))
+	followHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("tab"), helpDescStyle.Render("follow renames"))
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, selectHelp, " • ", followHelp, " • ", backHelp, " • ", quitHelp)
+	statusBar := statusBarStyle.Render(helpText)
+
+	main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar)
+	return appStyle.Render(main)
+}
@@ -11,18 +11,20 @@ import (
 // FormatModel handles the format selection view
 type FormatModel struct {
 	BaseModel
-	formats        []string
-	cursor         int
-	selectedFormat string
-	selectedTopic  string
+	formats         []string
+	cursor          int
+	chosenFormats   map[int]bool // Formats marked with v/space, by index into formats
+	selectedFormats []string
+	selectedTopic   string
 }
 
 // NewFormatModel creates a new format model
 func NewFormatModel(base BaseModel) *FormatModel {
 	return &FormatModel{
 		BaseModel: base,
-		formats:   []string{ContentFormatBlogArticle, ContentFormatTwitterThread, ContentFormatLinkedInPost, ContentFormatTechnicalDocs},
-		cursor:    0,
+		formats:       []string{ContentFormatBlogArticle, ContentFormatTwitterThread, ContentFormatLinkedInPost, ContentFormatTechnicalDocs},
+		cursor:        0,
+		chosenFormats: make(map[int]bool),
 	}
 }
 
@@ -48,12 +50,18 @@ func (m *FormatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.formats) > 0 {
 				m.cursor = len(m.formats) - 1
 			}
+		case "v", " ":
+			if m.chosenFormats[m.cursor] {
+				delete(m.chosenFormats, m.cursor)
+			} else {
+				m.chosenFormats[m.cursor] = true
+			}
 		case "enter":
 			if len(m.formats) > 0 {
-				m.selectedFormat = m.formats[m.cursor]
+				m.selectedFormats = m.chosen()
 				return m, func() tea.Msg { return NextMsg{} }
 			}
-		case "escape":
+		case "esc":
 			return m, func() tea.Msg { return BackMsg{} }
 		}
 	}
@@ -71,16 +79,20 @@ func (m *FormatModel) View() string {
 	subtitle := subtitleStyle.Render(fmt.Sprintf("Topic: %s", m.selectedTopic))
 	
 	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
-	headerWithBg := headerStyle.Width(100).Align(lipgloss.Left).Render(headerContent)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
 	
 	var formatRows []string
 	for i, format := range m.formats {
 		isSelected := i == m.cursor
+		isChosen := m.chosenFormats[i]
 		
 		cursor := "  "
 		if isSelected {
 			cursor = "▶ "
 		}
+		if isChosen {
+			cursor += "✓ "
+		}
 		
 		var formatText string
 		if isSelected {
@@ -107,7 +119,10 @@ func (m *FormatModel) View() string {
 		rowContent := lipgloss.JoinVertical(lipgloss.Left, firstLine, secondLine)
 		
 		if isSelected {
-			row := selectedCommitRowStyle.Width(96).Align(lipgloss.Left).Render(rowContent)
+			row := selectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(rowContent)
+			formatRows = append(formatRows, row)
+		} else if isChosen {
+			row := multiSelectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(rowContent)
 			formatRows = append(formatRows, row)
 		} else {
 			row := commitRowStyle.Render(rowContent)
@@ -115,16 +130,20 @@ func (m *FormatModel) View() string {
 		}
 	}
 	
-	content := contentStyle.Render(lipgloss.JoinVertical(lipgloss.Left, formatRows...))
+	content := contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, formatRows...))
 	
 	navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
-	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("select"))
+	chooseHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("v"), helpDescStyle.Render("add format"))
+	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("generate"))
 	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
 	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
 	
 	position := positionStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.formats)))
+	if len(m.chosenFormats) > 0 {
+		position += positionStyle.Render(fmt.Sprintf(" • %d chosen", len(m.chosenFormats)))
+	}
 	
-	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", selectHelp, " • ", backHelp, " • ", quitHelp)
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", chooseHelp, " • ", selectHelp, " • ", backHelp, " • ", quitHelp)
 	statusContent := lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		helpText,
@@ -142,7 +161,33 @@ func (m *FormatModel) SetSelectedTopic(topic string) {
 	m.selectedTopic = topic
 }
 
-// GetSelectedFormat returns the selected format
-func (m *FormatModel) GetSelectedFormat() string {
-	return m.selectedFormat
-}
\ No newline at end of file
+// GetSelectedFormats returns the formats to generate, in list order
+func (m *FormatModel) GetSelectedFormats() []string {
+	return m.selectedFormats
+}
+
+// chosen returns the formats marked with v/space in list order, or the format
+// under the cursor when none are marked
+func (m *FormatModel) chosen() []string {
+	if len(m.chosenFormats) == 0 {
+		return []string{m.formats[m.cursor]}
+	}
+	var formats []string
+	for i, format := range m.formats {
+		if m.chosenFormats[i] {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// helpBindings lists the keys for choosing one or more formats
+func (m *FormatModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"g / G", "first / last format"},
+		{"v / space", "add or remove format"},
+		{"enter", "generate the chosen formats, or the one under the cursor"},
+		{"esc", "back to topics"},
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpBinding describes a key and what it does in the current view
+type helpBinding struct {
+	key  string
+	desc string
+}
+
+// helpProvider is implemented by views that can list their key bindings in
+// the help overlay
+type helpProvider interface {
+	helpBindings() []helpBinding
+}
+
+// globalHelpBindings are available in every view
+var globalHelpBindings = []helpBinding{
+	{"?", "toggle this help"},
+	{"q / ctrl+c", "quit"},
+}
+
+// renderHelpOverlay renders the key bindings as a card centered in a
+// width×height area, falling back to the default layout size when the
+// terminal size is not yet known
+func renderHelpOverlay(title string, bindings []helpBinding, width, height int) string {
+	if width == 0 || height == 0 {
+		width, height = 100, 30
+	}
+
+	all := append(append([]helpBinding{}, bindings...), globalHelpBindings...)
+
+	keyWidth := 0
+	for _, binding := range all {
+		if w := lipgloss.Width(binding.key); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	var rows []string
+	for _, binding := range all {
+		key := helpKeyStyle.Render(fmt.Sprintf("%-*s", keyWidth, binding.key))
+		rows = append(rows, key+"  "+helpDescStyle.Render(binding.desc))
+	}
+
+	heading := lipgloss.NewStyle().
+		Foreground(primaryColor).
+		Bold(true).
+		Render(fmt.Sprintf("⌨ %s keys", title))
+
+	helpCard := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 3)
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		heading,
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		helpDescStyle.Render("Press '?' or 'esc' to close"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, helpCard.Render(content))
+}
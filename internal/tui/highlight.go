@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// codeHighlightStyle is the chroma style used for fenced code blocks
+const codeHighlightStyle = "monokai"
+
+// codeFence matches the opening or closing line of a fenced code block and
+// captures its language tag, e.g. "```go" or "~~~ python"
+var codeFence = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([\\w+#.-]*)")
+
+// renderContent prepares generated markdown for a viewport: prose is wrapped
+// to width, and fenced code blocks with a language tag are syntax highlighted.
+// Code lines are left unwrapped so indentation and ANSI sequences stay intact.
+func renderContent(content string, width int) string {
+	var rendered []string
+	var prose, code []string
+	var fence, language string
+
+	flushProse := func() {
+		if len(prose) > 0 {
+			rendered = append(rendered, wordwrap.String(strings.Join(prose, "\n"), width))
+			prose = nil
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		match := codeFence.FindStringSubmatch(line)
+		switch {
+		case fence == "" && match != nil:
+			// Opening fence, the fence line itself is shown as is
+			flushProse()
+			fence, language = match[1], match[2]
+			rendered = append(rendered, line)
+		case fence != "" && match != nil && strings.HasPrefix(match[1], fence) && match[2] == "":
+			// Closing fence
+			rendered = append(rendered, highlightCode(strings.Join(code, "\n"), language))
+			rendered = append(rendered, line)
+			fence, language, code = "", "", nil
+		case fence != "":
+			code = append(code, line)
+		default:
+			prose = append(prose, line)
+		}
+	}
+
+	// An unterminated block, e.g. from a truncated response, runs to the end
+	if fence != "" {
+		rendered = append(rendered, highlightCode(strings.Join(code, "\n"), language))
+	}
+	flushProse()
+
+	return strings.Join(rendered, "\n")
+}
+
+// highlightCode colorizes code for the terminal using the lexer for language.
+// Code without a language tag, or with a tag chroma doesn't know, is returned
+// unchanged.
+func highlightCode(code, language string) string {
+	if language == "" || code == "" {
+		return code
+	}
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return code
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		core.GetLogger().Debug("Failed to tokenise code block", "language", language, "error", err)
+		return code
+	}
+
+	var highlighted strings.Builder
+	if err := formatters.TTY256.Format(&highlighted, styles.Get(codeHighlightStyle), iterator); err != nil {
+		core.GetLogger().Debug("Failed to highlight code block", "language", language, "error", err)
+		return code
+	}
+	// The formatter keeps the trailing newline the lexer adds to the last line
+	return strings.TrimSuffix(highlighted.String(), "\n")
+}
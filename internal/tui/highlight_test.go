@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+func TestHighlightCode(t *testing.T) {
+	code := "func main() {\n\tfmt.Println(\"hi\")\n}"
+
+	highlighted := highlightCode(code, "go")
+	if !strings.Contains(highlighted, "\x1b[") {
+		t.Errorf("Expected ANSI colors in highlighted Go code, got %q", highlighted)
+	}
+	if stripANSI(highlighted) != code {
+		t.Errorf("Expected the code text to be kept, got %q", stripANSI(highlighted))
+	}
+
+	for _, language := range []string{"", "not-a-language"} {
+		if got := highlightCode(code, language); got != code {
+			t.Errorf("Expected code tagged %q unchanged, got %q", language, got)
+		}
+	}
+}
+
+func TestRenderContent(t *testing.T) {
+	content := strings.Join([]string{
+		"Retries now back off exponentially.",
+		"```go",
+		"delay := base * time.Duration(1<<attempt)",
+		"```",
+		"And a config example:",
+		"```not-a-language",
+		"retries = 3",
+		"```",
+	}, "\n")
+
+	rendered := renderContent(content, 80)
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != 8 {
+		t.Fatalf("Expected 8 lines, got %d: %q", len(lines), rendered)
+	}
+	if lines[1] != "```go" || lines[3] != "```" {
+		t.Errorf("Expected the fence lines as is, got %q and %q", lines[1], lines[3])
+	}
+	if !strings.Contains(lines[2], "\x1b[") || stripANSI(lines[2]) != "delay := base * time.Duration(1<<attempt)" {
+		t.Errorf("Expected the Go line highlighted, got %q", lines[2])
+	}
+	if lines[6] != "retries = 3" {
+		t.Errorf("Expected the block with an unknown language unchanged, got %q", lines[6])
+	}
+
+	wrapped := renderContent(strings.Repeat("word ", 30), 40)
+	for _, line := range strings.Split(wrapped, "\n") {
+		if len(line) > 40 {
+			t.Errorf("Expected prose wrapped to 40 columns, got %q", line)
+		}
+	}
+}
+
+// stripANSI removes terminal escape sequences from s
+func stripANSI(s string) string {
+	var b strings.Builder
+	inSequence := false
+	for _, r := range s {
+		switch {
+		case r == ansi.Marker:
+			inSequence = true
+		case inSequence:
+			inSequence = !ansi.IsTerminator(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
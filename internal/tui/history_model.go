@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/history"
+)
+
+// historyListLimit caps how many generations the history view loads
+const historyListLimit = 200
+
+// HistoryModel handles the history view, listing past generations saved in
+// the history database with a search filter and a detail view
+type HistoryModel struct {
+	BaseModel
+	generations []history.Generation
+	cursor      int
+	viewport    int
+	maxViewport int
+	searchInput textinput.Model
+	showDetail  bool
+	detail      viewport.Model
+}
+
+// NewHistoryModel creates a new history model
+func NewHistoryModel(base BaseModel) *HistoryModel {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search topic, format, content or commit"
+	ti.Width = 60
+
+	return &HistoryModel{
+		BaseModel:   base,
+		maxViewport: 8,
+		searchInput: ti,
+		detail:      viewport.New(94, 14),
+	}
+}
+
+func (m *HistoryModel) Init() tea.Cmd {
+	m.showDetail = false
+	m.loadGenerations()
+	return nil
+}
+
+func (m *HistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.searchInput.Focused() {
+		switch keyMsg.String() {
+		case "enter", "esc":
+			m.searchInput.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(keyMsg)
+		m.loadGenerations()
+		return m, cmd
+	}
+
+	if m.showDetail {
+		if keyMsg.String() == "esc" {
+			m.showDetail = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.Update(keyMsg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			if m.cursor < m.viewport {
+				m.viewport = m.cursor
+			}
+		}
+	case "down", "j":
+		if m.cursor < len(m.generations)-1 {
+			m.cursor++
+			if m.cursor >= m.viewport+m.maxViewport {
+				m.viewport = m.cursor - m.maxViewport + 1
+			}
+		}
+	case "/":
+		return m, m.searchInput.Focus()
+	case "enter":
+		if len(m.generations) > 0 {
+			generation := m.generations[m.cursor]
+			m.detail.Width = m.layout.wrapWidth()
+			m.detail.SetContent(wordwrap.String(generation.Content, m.layout.wrapWidth()))
+			m.detail.GotoTop()
+			m.showDetail = true
+		}
+	case "esc":
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+	return m, nil
+}
+
+// loadGenerations queries the history database with the current search term
+func (m *HistoryModel) loadGenerations() {
+	m.cursor = 0
+	m.viewport = 0
+	m.generations = nil
+
+	if m.history == nil {
+		return
+	}
+
+	generations, err := m.history.List(strings.TrimSpace(m.searchInput.Value()), historyListLimit)
+	if err != nil {
+		core.GetLogger().Error("Failed to load history", "error", err)
+		m.errorMsg = fmt.Sprintf("Failed to load history: %v", err)
+		return
+	}
+	m.errorMsg = ""
+	m.generations = generations
+}
+
+// isCapturingInput reports whether the search input has focus
+func (m *HistoryModel) isCapturingInput() bool {
+	return m.searchInput.Focused()
+}
+
+func (m *HistoryModel) View() string {
+	header := titleStyle.Render("🗄 History")
+	subtitle := subtitleStyle.Render(fmt.Sprintf("%d saved generations", len(m.generations)))
+	if m.showDetail && len(m.generations) > 0 {
+		generation := m.generations[m.cursor]
+		subtitle = subtitleStyle.Render(fmt.Sprintf("%s • %s • %s", generation.Topic, generation.Format, generation.CreatedAt.Format("Jan 02 2006, 15:04")))
+	}
+
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	var content string
+	var helpItems []string
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+
+	switch {
+	case m.history == nil:
+		content = emptyStyle.Render("History is off. Set COMMITLORE_HISTORY=1 to save generations to commitlore.db in the data directory (~/.commitlore by default)")
+		helpItems = []string{backHelp}
+	case m.errorMsg != "":
+		content = errorStyle.Render(fmt.Sprintf("⚠ %s", m.errorMsg))
+		helpItems = []string{backHelp}
+	case m.showDetail:
+		content = commitRowStyle.Width(m.layout.boxWidth()).Padding(1).Render(m.detail.View())
+		scrollHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓"), helpDescStyle.Render("scroll"))
+		helpItems = []string{scrollHelp, " • ", backHelp}
+	default:
+		content = m.renderList()
+		navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
+		openHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("open"))
+		searchHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("/"), helpDescStyle.Render("search"))
+		helpItems = []string{navHelp, " • ", openHelp, " • ", searchHelp, " • ", backHelp}
+	}
+
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+c"), helpDescStyle.Render("quit"))
+	helpItems = append(helpItems, " • ", quitHelp)
+	statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, helpItems...))
+
+	main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar)
+	return appStyle.Render(main)
+}
+
+// renderList renders the search line and the visible page of generations
+func (m *HistoryModel) renderList() string {
+	var rows []string
+	if m.searchInput.Focused() || m.searchInput.Value() != "" {
+		rows = append(rows, m.searchInput.View())
+	}
+
+	if len(m.generations) == 0 {
+		rows = append(rows, emptyStyle.Render("📭 No saved generations"))
+		return contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	}
+
+	end := m.viewport + m.maxViewport
+	if end > len(m.generations) {
+		end = len(m.generations)
+	}
+
+	for i := m.viewport; i < end; i++ {
+		generation := m.generations[i]
+
+		topic := truncateText(generation.Topic, 70)
+
+		cursor := "  "
+		subjectText := subjectStyle.Render(topic)
+		if i == m.cursor {
+			cursor = "▶ "
+			subjectText = selectedSubjectStyle.Render(topic)
+		}
+
+		firstLine := fmt.Sprintf("%s%s", cursor, subjectText)
+		secondLine := fmt.Sprintf("  %s • %s • %s",
+			authorStyle.Render(generation.Format),
+			dateStyle.Render(generation.CreatedAt.Format("Jan 02, 15:04")),
+			dateStyle.Render(fmt.Sprintf("%d commits", len(generation.CommitHashes))))
+		row := lipgloss.JoinVertical(lipgloss.Left, firstLine, secondLine)
+
+		if i == m.cursor {
+			row = selectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(row)
+		} else {
+			row = commitRowStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	return contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// helpBindings lists the keys for browsing and searching saved generations
+func (m *HistoryModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"/", "search"},
+		{"enter", "open generation"},
+		{"esc", "close generation, or back"},
+	}
+}
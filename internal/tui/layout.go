@@ -0,0 +1,46 @@
+package tui
+
+// Bounds for the width of a view's content. Narrower terminals are reported
+// as too small, and past the maximum lines get too long to read comfortably.
+const (
+	defaultContentWidth = 100
+	minContentWidth     = minTerminalWidth - 4
+	maxContentWidth     = 140
+	maxViewportHeight   = 40
+)
+
+// layout is the terminal size, shared by every view so their boxes and
+// wrapped text follow it. The app updates it on tea.WindowSizeMsg; until the
+// first one arrives the views use their default sizes.
+type layout struct {
+	width  int
+	height int
+}
+
+// contentWidth is the width of a view's header and content, inside the
+// app's padding
+func (l *layout) contentWidth() int {
+	if l == nil || l.width == 0 {
+		return defaultContentWidth
+	}
+	return min(max(l.width-appStyle.GetHorizontalFrameSize(), minContentWidth), maxContentWidth)
+}
+
+// boxWidth is the width of the bordered rows and boxes in a view's content
+func (l *layout) boxWidth() int {
+	return l.contentWidth() - 4
+}
+
+// wrapWidth is the width text is wrapped to inside a padded box
+func (l *layout) wrapWidth() int {
+	return l.contentWidth() - 6
+}
+
+// viewportHeight grows a scrolling area laid out for the minimum terminal
+// height by the rows the terminal has beyond it
+func (l *layout) viewportHeight(base int) int {
+	if l == nil || l.height <= minTerminalHeight {
+		return base
+	}
+	return min(base+l.height-minTerminalHeight, max(base, maxViewportHeight))
+}
@@ -1,15 +1,24 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
 	"github.com/sarkarshuvojit/commitlore/internal/core"
+	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 )
 
+// maxSelection is the most commits that can be selected at once
+const maxSelection = 5
+
 // ListingModel handles the commit listing view
 type ListingModel struct {
 	BaseModel
@@ -17,6 +26,7 @@ type ListingModel struct {
 	currentPage     int
 	perPage         int
 	totalCommits    int
+	hasMore         bool // Whether there is a page after the current one
 	cursor          int
 	viewport        int
 	maxViewport     int
@@ -24,6 +34,51 @@ type ListingModel struct {
 	selectionMode   bool
 	rangeStart      int
 	flashLimit      bool
+	changelistMode  changelistMode
+	pathFilter      string
+	followRenames   bool   // Follow pathFilter's history past renames
+	ref             string // Branch chosen in the branch picker, empty for HEAD
+	// metadataOnly marks commits, by hash, whose diff is left out of the
+	// changelist so only the message and file names are sent
+	metadataOnly    map[string]bool
+	// vagueWarning is shown after N when the selected commit messages are too
+	// terse to write about; pressing N again continues anyway
+	vagueWarning    bool
+	// commandInput takes ":" commands such as "page 3"
+	commandInput    textinput.Model
+	commandError    string
+	// expandedHash is the commit shown expanded inline, with its file stats
+	expandedHash    string
+	expandedStats   []core.FileStat
+	expandedError   string
+	// searchInput filters the current page by commit message; filtered holds
+	// the indices into commits that match, so selections keep referring to
+	// positions in commits. cursor, viewport and rangeStart index filtered.
+	searchInput     textinput.Model
+	filtered        []int
+	// preview shows the changelist that would be sent for the selection
+	showPreview     bool
+	preview         viewport.Model
+	// tokenCounts caches, by hash, diff token counts from a provider that
+	// implements llm.TokenCounter, counted when the preview is opened so diffs
+	// are only sent once the user looks at what would go out. Commits without
+	// a count use the estimate. The cache belongs to tokenCountsFor, the
+	// provider and model that counted, and pendingCounts holds hashes being
+	// counted.
+	tokenCounts     map[string]int
+	pendingCounts   map[string]bool
+	tokenCountsFor  string
+}
+
+// tokenCountTimeout bounds a provider token count request
+const tokenCountTimeout = 10 * time.Second
+
+// tokensCountedMsg carries a provider's token count for a commit's diff
+type tokensCountedMsg struct {
+	provider string
+	hash     string
+	tokens   int
+	err      error
 }
 
 // NewListingModel creates a new listing model
@@ -36,25 +91,124 @@ func NewListingModel(base BaseModel) *ListingModel {
 		viewport:        0,
 		maxViewport:     8,
 		selectedCommits: make(map[int]bool),
+		metadataOnly:    make(map[string]bool),
 		selectionMode:   false,
 		rangeStart:      -1,
 		flashLimit:      false,
+		commandInput:    newCommandInput(),
+		searchInput:     newSearchInput(),
+		preview:         viewport.New(94, 16),
 	}
 
 	m.loadCommits()
 	return m
 }
 
+// Init runs each time the listing is shown. The selection is kept, so coming
+// back from later views shows the commits that were picked; it is only cleared
+// by the user, e.g. with esc. Transient state from before is dropped.
 func (m *ListingModel) Init() tea.Cmd {
+	m.flashLimit = false
+	m.vagueWarning = false
+	m.showPreview = false
+	m.commandError = ""
+	for index := range m.selectedCommits {
+		if index >= len(m.commits) {
+			delete(m.selectedCommits, index)
+		}
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+	if m.cursor < m.viewport || m.cursor >= m.viewport+m.maxViewport {
+		m.viewport = max(m.cursor-m.maxViewport+1, 0)
+	}
 	return nil
 }
 
+// countSelectedTokens asks a provider that can count tokens to count the
+// diffs of selected commits that haven't been counted yet. Metadata only
+// commits and the uncommitted changes, which keep changing, are estimated.
+// Failed counts aren't cached, so opening the preview again retries them.
+func (m *ListingModel) countSelectedTokens() tea.Cmd {
+	counter, ok := m.llmProvider.(llm.TokenCounter)
+	if !ok || m.dryRun {
+		return nil
+	}
+	provider := m.providerStatus()
+	if provider != m.tokenCountsFor {
+		m.tokenCounts = make(map[string]int)
+		m.pendingCounts = make(map[string]bool)
+		m.tokenCountsFor = provider
+	}
+
+	var cmds []tea.Cmd
+	for index := range m.selectedCommits {
+		if index >= len(m.commits) {
+			continue
+		}
+		hash := m.commits[index].Hash
+		if hash == core.WorkingTreeHash || m.metadataOnly[hash] || m.pendingCounts[hash] {
+			continue
+		}
+		if _, counted := m.tokenCounts[hash]; counted {
+			continue
+		}
+		m.pendingCounts[hash] = true
+		repoPath := m.repoPath
+		cmds = append(cmds, func() tea.Msg {
+			diff, err := core.GetCommitDiff(repoPath, hash)
+			if err != nil {
+				return tokensCountedMsg{provider: provider, hash: hash, err: err}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tokenCountTimeout)
+			defer cancel()
+			tokens, err := counter.CountTokens(ctx, string(diff))
+			if err != nil {
+				core.GetLogger().Warn("Falling back to the token estimate", "commit", hash, "provider", provider, "error", err)
+			}
+			return tokensCountedMsg{provider: provider, hash: hash, tokens: tokens, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tokensCountedMsg:
+		if msg.provider == m.tokenCountsFor {
+			delete(m.pendingCounts, msg.hash)
+			if msg.err == nil {
+				m.tokenCounts[msg.hash] = msg.tokens
+			}
+		}
+		return m, nil
 	case flashTimerMsg:
 		m.flashLimit = false
 		return m, nil
 	case tea.KeyMsg:
+		if m.commandInput.Focused() {
+			return m.updateCommandInput(msg)
+		}
+		if m.searchInput.Focused() {
+			return m.updateSearchInput(msg)
+		}
+		if m.showPreview {
+			switch msg.String() {
+			case "esc", "P":
+				m.showPreview = false
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		}
+		m.commandError = ""
+
+		if msg.String() != "n" && msg.String() != "N" {
+			m.vagueWarning = false
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -64,7 +218,7 @@ func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		case "down", "j":
-			if m.cursor < len(m.commits)-1 {
+			if m.cursor < len(m.filtered)-1 {
 				m.cursor++
 				if m.cursor >= m.viewport+m.maxViewport {
 					m.viewport = m.cursor - m.maxViewport + 1
@@ -74,20 +228,24 @@ func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = 0
 			m.viewport = 0
 		case "end", "G":
-			if len(m.commits) > 0 {
-				m.cursor = len(m.commits) - 1
-				if len(m.commits) > m.maxViewport {
-					m.viewport = len(m.commits) - m.maxViewport
+			if len(m.filtered) > 0 {
+				m.cursor = len(m.filtered) - 1
+				if len(m.filtered) > m.maxViewport {
+					m.viewport = len(m.filtered) - m.maxViewport
 				} else {
 					m.viewport = 0
 				}
 			}
 		case "v":
-			if len(m.selectedCommits) < 5 || m.selectedCommits[m.cursor] {
-				if m.selectedCommits[m.cursor] {
-					delete(m.selectedCommits, m.cursor)
+			if len(m.filtered) == 0 {
+				return m, nil
+			}
+			index := m.filtered[m.cursor]
+			if len(m.selectedCommits) < maxSelection || m.selectedCommits[index] {
+				if m.selectedCommits[index] {
+					delete(m.selectedCommits, index)
 				} else {
-					m.selectedCommits[m.cursor] = true
+					m.selectedCommits[index] = true
 				}
 			} else {
 				m.flashLimit = true
@@ -96,10 +254,13 @@ func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				})
 			}
 		case "V":
+			if len(m.filtered) == 0 {
+				return m, nil
+			}
 			if !m.selectionMode {
 				m.selectionMode = true
 				m.rangeStart = m.cursor
-				m.selectedCommits[m.cursor] = true
+				m.selectedCommits[m.filtered[m.cursor]] = true
 			} else {
 				start := m.rangeStart
 				end := m.cursor
@@ -108,9 +269,9 @@ func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				rangeSize := end - start + 1
-				if len(m.selectedCommits)+rangeSize <= 5 {
+				if len(m.selectedCommits)+rangeSize <= maxSelection {
 					for i := start; i <= end; i++ {
-						m.selectedCommits[i] = true
+						m.selectedCommits[m.filtered[i]] = true
 					}
 				} else {
 					m.flashLimit = true
@@ -123,18 +284,110 @@ func (m *ListingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectionMode = false
 				m.rangeStart = -1
 			}
+		case "a":
+			// Select the listed commits from the top, up to the cap
+			for _, index := range m.filtered {
+				if m.selectedCommits[index] {
+					continue
+				}
+				if len(m.selectedCommits) >= maxSelection {
+					m.flashLimit = true
+					return m, tea.Tick(time.Millisecond*300, func(t time.Time) tea.Msg {
+						return flashTimerMsg{}
+					})
+				}
+				m.selectedCommits[index] = true
+			}
+		case "A":
+			m.selectionMode = false
+			m.rangeStart = -1
+			m.selectedCommits = make(map[int]bool)
 		case "d":
-			if m.selectedCommits[m.cursor] {
-				delete(m.selectedCommits, m.cursor)
+			if len(m.filtered) > 0 {
+				delete(m.selectedCommits, m.filtered[m.cursor])
+			}
+		case "D":
+			if len(m.filtered) > 0 {
+				hash := m.commits[m.filtered[m.cursor]].Hash
+				if m.metadataOnly[hash] {
+					delete(m.metadataOnly, hash)
+				} else {
+					m.metadataOnly[hash] = true
+				}
+			}
+		case "esc":
+			// Clear the search first, then the selection
+			if m.searchInput.Value() != "" {
+				m.setSearch("")
+				return m, nil
 			}
-		case "escape":
 			m.selectionMode = false
 			m.rangeStart = -1
 			m.selectedCommits = make(map[int]bool)
+			m.metadataOnly = make(map[string]bool)
 		case "n", "N":
 			if len(m.selectedCommits) > 0 {
+				if !m.vagueWarning && m.hasVagueSelection() {
+					m.vagueWarning = true
+					return m, nil
+				}
+				m.vagueWarning = false
+				m.changelistMode = changelistCommits
 				return m, func() tea.Msg { return NextMsg{} }
 			}
+		case "c":
+			// Compare exactly two commits as a single diff
+			if len(m.selectedCommits) != 2 {
+				m.flashLimit = true
+				return m, tea.Tick(time.Millisecond*300, func(t time.Time) tea.Msg {
+					return flashTimerMsg{}
+				})
+			}
+			m.changelistMode = changelistCompare
+			return m, func() tea.Msg { return NextMsg{} }
+		case "i":
+			// Analyze the selection without generating content
+			if len(m.selectedCommits) == 0 {
+				m.flashLimit = true
+				return m, tea.Tick(time.Millisecond*300, func(t time.Time) tea.Msg {
+					return flashTimerMsg{}
+				})
+			}
+			return m, func() tea.Msg { return AnalyzeMsg{} }
+		case "e":
+			m.toggleExpanded()
+		case "P":
+			// Preview exactly what will be sent for the selected commits
+			if len(m.selectedCommits) == 0 {
+				m.flashLimit = true
+				return m, tea.Tick(time.Millisecond*300, func(t time.Time) tea.Msg {
+					return flashTimerMsg{}
+				})
+			}
+			m.openPreview()
+			return m, m.countSelectedTokens()
+		case "/":
+			return m, m.searchInput.Focus()
+		case ":":
+			m.commandError = ""
+			m.commandInput.Reset()
+			return m, m.commandInput.Focus()
+		case "o":
+			// Generate an overview from a sample of all commits on the page
+			m.changelistMode = changelistOverview
+			return m, func() tea.Msg { return NextMsg{} }
+		case "pgdown", "]":
+			if !m.hasMore {
+				m.flashLimit = true
+				return m, tea.Tick(time.Millisecond*300, func(t time.Time) tea.Msg {
+					return flashTimerMsg{}
+				})
+			}
+			m.commandError = m.goToPage(m.currentPage + 1)
+		case "pgup", "[":
+			if m.currentPage > 1 {
+				m.commandError = m.goToPage(m.currentPage - 1)
+			}
 		}
 	}
 	return m, nil
@@ -154,15 +407,250 @@ func (m *ListingModel) View() string {
 	}
 
 	header := m.renderHeader()
+	if m.showPreview {
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, header, m.renderPreview()))
+	}
 	content := m.renderCommitList()
 	statusBar := m.renderStatusBar()
 
-	main := lipgloss.JoinVertical(lipgloss.Left, header, content, statusBar)
+	sections := []string{header, content}
+	if m.vagueWarning {
+		sections = append(sections, m.renderVagueWarning())
+	}
+	if m.searchInput.Focused() || m.searchInput.Value() != "" {
+		sections = append(sections, m.renderSearchLine())
+	}
+	if m.commandInput.Focused() || m.commandError != "" {
+		sections = append(sections, m.renderCommandLine())
+	}
+	sections = append(sections, statusBar)
+
+	main := lipgloss.JoinVertical(lipgloss.Left, sections...)
 	return appStyle.Render(main)
 }
 
+// newCommandInput creates the input used for ":" commands
+func newCommandInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Placeholder = "page N"
+	ti.Width = 40
+	return ti
+}
+
+// openPreview renders the changelist for the selected commits, built the same
+// way as for topic extraction, into the preview viewport
+func (m *ListingModel) openPreview() {
+	data := buildChangelistData(m.repoPath, m.commits, m.selectedCommits, changelistOptions{
+		mode:         changelistCommits,
+		scope:        changelistScopeFull,
+		hashLength:   m.hashLength,
+		metadataOnly: m.metadataOnly,
+	})
+	m.preview.Width = m.layout.wrapWidth()
+	m.preview.SetContent(wordwrap.String(data, m.layout.wrapWidth()))
+	m.preview.GotoTop()
+	m.showPreview = true
+}
+
+// renderPreview renders the changelist preview with its size and scroll position
+func (m *ListingModel) renderPreview() string {
+	title := subjectStyle.Render(fmt.Sprintf("🔎 Preview of %d selected commits • 🪙 %s tokens",
+		len(m.selectedCommits), core.FormatTokenCount(m.calculateTokensForSelection())))
+	box := commitRowStyle.Width(m.layout.boxWidth()).Padding(1).Render(m.preview.View())
+	content := contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, title, box))
+
+	scrollHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/pgup/pgdn"), helpDescStyle.Render("scroll"))
+	closeHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc/P"), helpDescStyle.Render("close"))
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
+	position := positionStyle.Render(fmt.Sprintf("%3.f%%", m.preview.ScrollPercent()*100))
+	statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left,
+		scrollHelp, " • ", closeHelp, " • ", quitHelp, strings.Repeat(" ", 10), position))
+
+	return lipgloss.JoinVertical(lipgloss.Left, content, statusBar)
+}
+
+// newSearchInput creates the input used for "/" searches
+func newSearchInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search commit messages"
+	ti.Width = 40
+	return ti
+}
+
+// updateSearchInput handles keys while a search is being typed, filtering the
+// list as the query changes. Enter keeps the filter, esc clears it.
+func (m *ListingModel) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searchInput.Blur()
+		return m, nil
+	case "esc":
+		m.searchInput.Blur()
+		m.setSearch("")
+		return m, nil
+	}
+
+	query := m.searchInput.Value()
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.searchInput.Value() != query {
+		m.applyFilter()
+	}
+	return m, cmd
+}
+
+// setSearch replaces the search query and refilters the list
+func (m *ListingModel) setSearch(query string) {
+	m.searchInput.SetValue(query)
+	m.applyFilter()
+}
+
+// applyFilter recomputes which commits on the page match the search query,
+// case-insensitively against the subject and body, and resets the cursor to
+// the top of the filtered list. Selections are kept.
+func (m *ListingModel) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.searchInput.Value()))
+
+	m.filtered = m.filtered[:0]
+	for i, commit := range m.commits {
+		if query == "" ||
+			strings.Contains(strings.ToLower(commit.Subject), query) ||
+			strings.Contains(strings.ToLower(commit.Body), query) {
+			m.filtered = append(m.filtered, i)
+		}
+	}
+
+	m.cursor = 0
+	m.viewport = 0
+	m.selectionMode = false
+	m.rangeStart = -1
+}
+
+// renderSearchLine renders the search input, or the active query and its
+// match count once the input is closed
+func (m *ListingModel) renderSearchLine() string {
+	line := m.searchInput.View()
+	if !m.searchInput.Focused() {
+		line = fmt.Sprintf("%s %s",
+			helpKeyStyle.Render("/"+m.searchInput.Value()),
+			helpDescStyle.Render(fmt.Sprintf("%d of %d commits on this page match • esc to clear", len(m.filtered), len(m.commits))))
+	}
+	return lipgloss.NewStyle().Padding(0, 2).Render(line)
+}
+
+// updateCommandInput handles keys while a ":" command is being typed
+func (m *ListingModel) updateCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.commandError = m.runCommand(m.commandInput.Value())
+		m.commandInput.Blur()
+		return m, nil
+	case "esc":
+		m.commandError = ""
+		m.commandInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return m, cmd
+}
+
+// runCommand executes a ":" command and returns an error message, if any.
+// Supported commands are "page N" and a bare page number.
+func (m *ListingModel) runCommand(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	if fields[0] == "page" || fields[0] == "p" {
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return fmt.Sprintf("Unknown command %q, try \"page N\"", command)
+	}
+
+	page, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return fmt.Sprintf("Invalid page number %q", fields[0])
+	}
+	return m.goToPage(page)
+}
+
+// goToPage loads the given page of commits and returns an error message if
+// the page is out of range. The selection is cleared since it refers to
+// positions on the current page.
+func (m *ListingModel) goToPage(page int) string {
+	totalPages := m.totalPages()
+	// The total can lag behind a repo that gained commits, so a next page
+	// that git reported is always allowed
+	if page == m.currentPage+1 && m.hasMore {
+		totalPages = max(totalPages, page)
+	}
+	if page < 1 || page > totalPages {
+		return fmt.Sprintf("Page %d is out of range (1-%d)", page, totalPages)
+	}
+
+	m.currentPage = page
+	m.cursor = 0
+	m.viewport = 0
+	m.selectedCommits = make(map[int]bool)
+	m.selectionMode = false
+	m.rangeStart = -1
+	m.loadCommits()
+	return ""
+}
+
+// totalPages returns the number of pages in the listing, at least 1
+func (m *ListingModel) totalPages() int {
+	if m.totalCommits <= 0 || m.perPage <= 0 {
+		return 1
+	}
+	return (m.totalCommits + m.perPage - 1) / m.perPage
+}
+
+// renderCommandLine renders the ":" command input or the last command error
+func (m *ListingModel) renderCommandLine() string {
+	if m.commandInput.Focused() {
+		return lipgloss.NewStyle().Padding(0, 2).Render(m.commandInput.View())
+	}
+	return lipgloss.NewStyle().Padding(0, 2).Render(errorStyle.Render(fmt.Sprintf("⚠ %s", m.commandError)))
+}
+
+// isCapturingInput reports whether a ":" command or a search is being typed
+func (m *ListingModel) isCapturingInput() bool {
+	return m.commandInput.Focused() || m.searchInput.Focused()
+}
+
+// hasVagueSelection reports whether the selected commits' messages are, on
+// average, too terse to yield good content
+func (m *ListingModel) hasVagueSelection() bool {
+	var selected []core.Commit
+	for _, index := range sortedSelection(m.commits, m.selectedCommits) {
+		// The uncommitted changes have no message to judge
+		if m.commits[index].Hash != core.WorkingTreeHash {
+			selected = append(selected, m.commits[index])
+		}
+	}
+	return len(selected) > 0 && core.AverageMessageScore(selected) < core.VagueMessageThreshold
+}
+
+// renderVagueWarning explains why the selection may produce weak content
+func (m *ListingModel) renderVagueWarning() string {
+	warning := lipgloss.NewStyle().
+		Foreground(warningColor).
+		Bold(true).
+		Render("⚡ These commit messages are too vague to write about (e.g. \"fix\", \"wip\", \"update\")")
+	advice := helpDescStyle.Render("The content will rely on the diffs alone. Consider rewording the messages or attaching notes as context.")
+	continueHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("N"), helpDescStyle.Render("continue anyway"))
+	return lipgloss.NewStyle().Padding(0, 2).Render(lipgloss.JoinVertical(lipgloss.Left, warning, advice, continueHelp))
+}
+
 func (m *ListingModel) loadCommits() {
-	page, err := core.GetCommitLogs(m.repoPath, m.perPage, m.currentPage)
+	opts := core.CommitLogOptions{Path: m.pathFilter, Range: m.revisionRange, Ref: m.ref, Follow: m.followRenames}
+	page, err := core.GetCommitLogsWithOptions(m.repoPath, opts, m.perPage, m.currentPage)
 	if err != nil {
 		m.errorMsg = fmt.Sprintf("Error loading commits: %v", err)
 		return
@@ -170,24 +658,56 @@ func (m *ListingModel) loadCommits() {
 
 	m.commits = page.Commits
 	m.totalCommits = page.Total
+	m.hasMore = page.HasMore
+
+	// Offer the uncommitted changes above the newest commit
+	if m.currentPage == 1 && m.pathFilter == "" && m.revisionRange == "" && m.ref == "" {
+		working, err := core.GetWorkingTreeCommit(m.repoPath)
+		if err != nil {
+			core.GetLogger().Warn("Failed to check for uncommitted changes", "error", err)
+		} else if working != nil {
+			m.commits = append([]core.Commit{*working}, m.commits...)
+		}
+	}
+
 	m.errorMsg = ""
+	m.expandedHash = ""
+	m.expandedStats = nil
+	m.showPreview = false
+	m.applyFilter()
 }
 
 func (m *ListingModel) renderHeader() string {
 	title := titleStyle.Render("✨ CommitLore")
-	subtitle := subtitleStyle.Render(fmt.Sprintf("Page %d • %d commits total", m.currentPage, m.totalCommits))
+	subtitleText := fmt.Sprintf("Page %d/%d • %d commits total", m.currentPage, m.totalPages(), m.totalCommits)
+	if m.revisionRange != "" {
+		subtitleText += fmt.Sprintf(" • 🔀 %s", m.revisionRange)
+	} else if m.ref != "" {
+		subtitleText += fmt.Sprintf(" • 🌿 %s", m.ref)
+	}
+	if m.pathFilter != "" {
+		subtitleText += fmt.Sprintf(" • 📄 %s", m.pathFilter)
+		if m.followRenames {
+			subtitleText += " (following renames)"
+		}
+	}
+	subtitle := subtitleStyle.Render(subtitleText)
 
 	headerContent := lipgloss.JoinVertical(lipgloss.Left, title, subtitle)
-	headerWithBg := headerStyle.Width(100).Align(lipgloss.Left).Render(headerContent)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
 
 	return headerWithBg
 }
 
 func (m *ListingModel) renderCommitList() string {
+	if len(m.filtered) == 0 {
+		return contentStyle.Width(m.layout.contentWidth()).Render(emptyStyle.Render("🔍 No commits on this page match the search"))
+	}
+
 	start := m.viewport
 	end := start + m.maxViewport
-	if end > len(m.commits) {
-		end = len(m.commits)
+	if end > len(m.filtered) {
+		end = len(m.filtered)
 	}
 	if start < 0 {
 		start = 0
@@ -196,20 +716,23 @@ func (m *ListingModel) renderCommitList() string {
 	var rows []string
 
 	for i := start; i < end; i++ {
-		commit := m.commits[i]
+		commit := m.commits[m.filtered[i]]
 		isSelected := i == m.cursor
-		isMultiSelected := m.selectedCommits[i]
+		isMultiSelected := m.selectedCommits[m.filtered[i]]
 		isInRange := m.selectionMode && ((m.rangeStart <= i && i <= m.cursor) || (m.cursor <= i && i <= m.rangeStart))
 
 		row := m.renderCommitRow(commit, isSelected, isMultiSelected, isInRange)
 		rows = append(rows, row)
+		if commit.Hash == m.expandedHash {
+			rows = append(rows, m.renderExpandedCommit(commit))
+		}
 	}
 
 	var scrollIndicators []string
 	if m.viewport > 0 {
 		scrollIndicators = append(scrollIndicators, scrollIndicatorStyle.Render("↑ More above"))
 	}
-	if end < len(m.commits) {
+	if end < len(m.filtered) {
 		scrollIndicators = append(scrollIndicators, scrollIndicatorStyle.Render("↓ More below"))
 	}
 
@@ -219,13 +742,91 @@ func (m *ListingModel) renderCommitList() string {
 		content = lipgloss.JoinVertical(lipgloss.Left, content, indicators)
 	}
 
-	return contentStyle.Render(content)
+	return contentStyle.Width(m.layout.contentWidth()).Render(content)
+}
+
+// toggleExpanded expands the commit under the cursor inline, or collapses it
+// if it is already expanded
+func (m *ListingModel) toggleExpanded() {
+	if len(m.filtered) == 0 {
+		return
+	}
+
+	commit := m.commits[m.filtered[m.cursor]]
+	if commit.Hash == m.expandedHash {
+		m.expandedHash = ""
+		m.expandedStats = nil
+		m.expandedError = ""
+		return
+	}
+
+	m.expandedHash = commit.Hash
+	m.expandedError = ""
+	stats, err := core.GetCommitFileStats(m.repoPath, commit.Hash)
+	if err != nil {
+		core.GetLogger().Error("Failed to get file stats for commit", "hash", commit.Hash, "error", err)
+		m.expandedError = fmt.Sprintf("Failed to load file stats: %v", err)
+	}
+	m.expandedStats = stats
+}
+
+// renderExpandedCommit renders the full message and per-file stats of a commit
+func (m *ListingModel) renderExpandedCommit(commit core.Commit) string {
+	const maxBodyLines = 8
+	const maxFiles = 8
+
+	var lines []string
+
+	body := strings.TrimSpace(commit.Body)
+	if body == "" {
+		lines = append(lines, dimStyle.Render("(no commit message body)"))
+	} else {
+		bodyLines := strings.Split(wordwrap.String(body, m.layout.wrapWidth()-8), "\n")
+		if len(bodyLines) > maxBodyLines {
+			bodyLines = append(bodyLines[:maxBodyLines], "…")
+		}
+		lines = append(lines, helpDescStyle.Render(strings.Join(bodyLines, "\n")))
+	}
+	lines = append(lines, "")
+
+	if m.expandedError != "" {
+		lines = append(lines, errorStyle.Render(m.expandedError))
+		return expandedCommitStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	insertions, deletions := 0, 0
+	for i, stat := range m.expandedStats {
+		insertions += stat.Insertions
+		deletions += stat.Deletions
+		if i >= maxFiles {
+			continue
+		}
+
+		change := dimStyle.Render("binary")
+		if !stat.Binary {
+			change = fmt.Sprintf("%s %s",
+				insertionStyle.Render(fmt.Sprintf("+%d", stat.Insertions)),
+				deletionStyle.Render(fmt.Sprintf("-%d", stat.Deletions)))
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s", change, subjectStyle.Render(stat.Path)))
+	}
+	if len(m.expandedStats) > maxFiles {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("… and %d more files", len(m.expandedStats)-maxFiles)))
+	}
+
+	summary := fmt.Sprintf("%d files changed, %s, %s",
+		len(m.expandedStats),
+		insertionStyle.Render(fmt.Sprintf("%d insertions(+)", insertions)),
+		deletionStyle.Render(fmt.Sprintf("%d deletions(-)", deletions)))
+	lines = append(lines, helpDescStyle.Render(summary))
+
+	return expandedCommitStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
 func (m *ListingModel) renderCommitRow(commit core.Commit, isSelected bool, isMultiSelected bool, isInRange bool) string {
-	subject := commit.Subject
-	if len(subject) > 70 {
-		subject = subject[:67] + "..."
+	subject := truncateText(commit.Subject, 70)
+	if commit.Hash == core.WorkingTreeHash {
+		subject = "● " + subject
 	}
 
 	author := commit.Author
@@ -233,7 +834,7 @@ func (m *ListingModel) renderCommitRow(commit core.Commit, isSelected bool, isMu
 		author = author[:17] + "..."
 	}
 
-	hash := commit.Hash[:7]
+	hash := core.AbbreviateHash(commit.Hash, m.hashLength)
 	date := commit.Date.Format("Jan 02, 15:04")
 
 	cursor := "  "
@@ -284,17 +885,35 @@ func (m *ListingModel) renderCommitRow(commit core.Commit, isSelected bool, isMu
 	}
 
 	firstLine := fmt.Sprintf("%s%s%s %s", cursor, selectionIndicator, hashText, subjectText)
-	secondLine := fmt.Sprintf("  %s • %s", authorText, dateText)
+	secondLine := fmt.Sprintf("  %s • %s • %s %s", authorText, dateText,
+		insertionStyle.Render(fmt.Sprintf("+%d", commit.Insertions)),
+		deletionStyle.Render(fmt.Sprintf("−%d", commit.Deletions)))
+	if commit.FilesChanged > 0 {
+		files := fmt.Sprintf("%d files", commit.FilesChanged)
+		if commit.FilesChanged == 1 {
+			files = "1 file"
+		}
+		if commit.TopDir != "" {
+			files += fmt.Sprintf(" in %s/", commit.TopDir)
+		}
+		secondLine += " • " + helpDescStyle.Render(files)
+	}
+	if m.metadataOnly[commit.Hash] {
+		secondLine += " • " + helpDescStyle.Render("no diff")
+	}
 
 	rowContent := lipgloss.JoinVertical(lipgloss.Left, firstLine, secondLine)
 
 	if needsFullWidth {
-		return style.Width(96).Align(lipgloss.Left).Render(rowContent)
+		return style.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(rowContent)
 	}
 
 	return style.Render(rowContent)
 }
 
+// calculateTokensForSelection sums the tokens of the selected commits, using
+// the provider's counts where the preview has fetched them and estimating
+// the rest
 func (m *ListingModel) calculateTokensForSelection() int {
 	if len(m.selectedCommits) == 0 {
 		return 0
@@ -304,6 +923,14 @@ func (m *ListingModel) calculateTokensForSelection() int {
 	for index := range m.selectedCommits {
 		if index < len(m.commits) {
 			commit := m.commits[index]
+			if m.metadataOnly[commit.Hash] {
+				totalTokens += estimateMetadataTokens(commit)
+				continue
+			}
+			if tokens, ok := m.tokenCounts[commit.Hash]; ok && m.tokenCountsFor == m.providerStatus() {
+				totalTokens += tokens
+				continue
+			}
 			diff, err := core.GetCommitDiff(m.repoPath, commit.Hash)
 			if err == nil {
 				tokens := core.EstimateTokenCount(string(diff))
@@ -315,6 +942,30 @@ func (m *ListingModel) calculateTokensForSelection() int {
 	return totalTokens
 }
 
+// calculateLinesForSelection sums the inserted and deleted lines of the
+// selected commits, binary files excluded
+func (m *ListingModel) calculateLinesForSelection() (int, int) {
+	insertions, deletions := 0, 0
+	for index := range m.selectedCommits {
+		if index < len(m.commits) {
+			insertions += m.commits[index].Insertions
+			deletions += m.commits[index].Deletions
+		}
+	}
+	return insertions, deletions
+}
+
+// estimateMetadataTokens estimates the tokens sent for a commit marked
+// metadata only: its message and the names of the files it changed. It
+// runs on every render, so the paths come from the loaded commit.
+func estimateMetadataTokens(commit core.Commit) int {
+	tokens := core.EstimateTokenCount(commit.Subject + "\n" + commit.Body)
+	for _, path := range commit.Paths {
+		tokens += core.EstimateTokenCount(path + ", ")
+	}
+	return tokens
+}
+
 func (m *ListingModel) renderStatusBar() string {
 	navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
 	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("v"), helpDescStyle.Render("select"))
@@ -322,6 +973,7 @@ func (m *ListingModel) renderStatusBar() string {
 	nextHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("N"), helpDescStyle.Render("next"))
 	clearHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("clear"))
 	providerHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("p"), helpDescStyle.Render("providers"))
+	keysHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("?"), helpDescStyle.Render("help"))
 	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
 
 	selectionCount := len(m.selectedCommits)
@@ -334,11 +986,14 @@ func (m *ListingModel) renderStatusBar() string {
 
 		tokenCount := m.calculateTokensForSelection()
 		tokenText := core.FormatTokenCount(tokenCount)
+		insertions, deletions := m.calculateLinesForSelection()
 
-		selectionText = fmt.Sprintf(" • %s • %s • %s", 
-			style.Render(fmt.Sprintf("%d/5 selected", selectionCount)),
+		selectionText = fmt.Sprintf(" • %s • %s %s %s • %s", 
+			style.Render(fmt.Sprintf("%d/%d selected", selectionCount, maxSelection)),
 			positionStyle.Render(fmt.Sprintf("Tokens: 🪙 %s", tokenText)),
-			positionStyle.Render(fmt.Sprintf("Provider: %s", m.llmProviderType)))
+			insertionStyle.Render(fmt.Sprintf("+%d", insertions)),
+			deletionStyle.Render(fmt.Sprintf("−%d", deletions)),
+			positionStyle.Render(fmt.Sprintf("Provider: %s", m.providerName())))
 	}
 
 	modeText := ""
@@ -346,9 +1001,34 @@ func (m *ListingModel) renderStatusBar() string {
 		modeText = fmt.Sprintf(" • %s", helpKeyStyle.Render("RANGE MODE"))
 	}
 
-	position := positionStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.commits)))
+	position := positionStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.filtered)))
 
-	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", selectHelp, " • ", rangeHelp, " • ", nextHelp, " • ", clearHelp, " • ", providerHelp, " • ", quitHelp)
+	expandHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("e"), helpDescStyle.Render("expand"))
+	searchHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("/"), helpDescStyle.Render("search"))
+	helpItems := []string{navHelp, " • ", selectHelp, " • ", rangeHelp, " • ", expandHelp, " • ", searchHelp, " • ", nextHelp}
+	if selectionCount > 0 {
+		previewHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("P"), helpDescStyle.Render("preview"))
+		analyzeHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("i"), helpDescStyle.Render("analyze"))
+		helpItems = append(helpItems, " • ", previewHelp, " • ", analyzeHelp)
+	}
+	if selectionCount == 2 {
+		compareHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("c"), helpDescStyle.Render("compare"))
+		helpItems = append(helpItems, " • ", compareHelp)
+	}
+	overviewHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("o"), helpDescStyle.Render("overview"))
+	helpItems = append(helpItems, " • ", overviewHelp)
+	if m.totalPages() > 1 {
+		pagingHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("pgup/pgdn"), helpDescStyle.Render("page"))
+		if !m.hasMore {
+			pagingHelp = fmt.Sprintf("%s %s", helpKeyStyle.Render("pgup"), helpDescStyle.Render("previous page"))
+		} else if m.currentPage == 1 {
+			pagingHelp = fmt.Sprintf("%s %s", helpKeyStyle.Render("pgdn"), helpDescStyle.Render("next page"))
+		}
+		pageHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render(":page N"), helpDescStyle.Render("go to page"))
+		helpItems = append(helpItems, " • ", pagingHelp, " • ", pageHelp)
+	}
+	helpItems = append(helpItems, " • ", clearHelp, " • ", providerHelp, " • ", keysHelp, " • ", quitHelp)
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, helpItems...)
 
 	rightSide := fmt.Sprintf("%s%s%s", position, selectionText, modeText)
 	statusContent := lipgloss.JoinHorizontal(
@@ -365,3 +1045,65 @@ func (m *ListingModel) renderStatusBar() string {
 func (m *ListingModel) GetSelectedCommits() ([]core.Commit, map[int]bool) {
 	return m.commits, m.selectedCommits
 }
+
+// GetMetadataOnly returns the hashes of commits to send without their diff
+func (m *ListingModel) GetMetadataOnly() map[string]bool {
+	return m.metadataOnly
+}
+
+// GetChangelistMode returns how the selection should be turned into a changelist
+func (m *ListingModel) GetChangelistMode() changelistMode {
+	return m.changelistMode
+}
+
+// SetPathFilter limits the listing to commits touching the given path,
+// following its renames when follow is set, and reloads from the first page.
+// An empty path shows all commits.
+func (m *ListingModel) SetPathFilter(path string, follow bool) {
+	m.pathFilter = path
+	m.followRenames = follow
+	m.currentPage = 1
+	m.cursor = 0
+	m.viewport = 0
+	m.selectedCommits = make(map[int]bool)
+	m.selectionMode = false
+	m.rangeStart = -1
+	m.loadCommits()
+}
+
+// SetRef lists the history of the given branch instead of HEAD
+func (m *ListingModel) SetRef(ref string) {
+	m.ref = ref
+	m.currentPage = 1
+	m.cursor = 0
+	m.viewport = 0
+	m.selectedCommits = make(map[int]bool)
+	m.selectionMode = false
+	m.rangeStart = -1
+	m.loadCommits()
+}
+
+// helpBindings lists the keys for selecting, searching and paging commits
+func (m *ListingModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"g / G", "first / last commit"},
+		{"v", "select or unselect commit"},
+		{"V", "start or finish a range selection"},
+		{"a", fmt.Sprintf("select the listed commits, up to %d", maxSelection)},
+		{"A", "unselect all commits"},
+		{"d", "unselect commit"},
+		{"D", "send commit without its diff"},
+		{"esc", "clear search, then selection"},
+		{"e", "expand commit details"},
+		{"/", "search messages"},
+		{"pgdn / ]", "next page of commits"},
+		{"pgup / [", "previous page of commits"},
+		{":", "run a command"},
+		{"P", "preview the changelist"},
+		{"n", "extract topics from selection"},
+		{"c", "compare two selected commits"},
+		{"i", "analyze selection as JSON, without generating content"},
+		{"o", "overview of the page"},
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+func TestMain(m *testing.M) {
+	homeDir, err := os.MkdirTemp("", "commitlore-tui-test-*")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", homeDir)
+
+	if err := core.InitLogger(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(homeDir)
+	os.Exit(code)
+}
+
+// createTestCommit creates a repository with one commit that changes two
+// files with a sizeable diff, and returns the repository and the commit
+func createTestCommit(t *testing.T) (string, core.Commit) {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	git := func(args ...string) string {
+		output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("git %s failed: %v", strings.Join(args, " "), err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	git("init")
+	git("config", "user.name", "Test User")
+	git("config", "user.email", "test@example.com")
+	for name, lines := range map[string]int{"internal/app.go": 200, "readme.md": 50} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("a line of changed code\n", lines)), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	git("add", ".")
+	git("commit", "-m", "Add the app\n\nIt starts the server and documents it.")
+
+	return repoPath, core.Commit{
+		Hash:    git("rev-parse", "HEAD"),
+		Subject: "Add the app",
+		Body:    "It starts the server and documents it.",
+		Paths:   []string{"internal/app.go", "readme.md"},
+	}
+}
+
+func TestEstimateMetadataTokens(t *testing.T) {
+	commit := core.Commit{
+		Hash:    "abc123",
+		Subject: "Add the app",
+		Body:    "It starts the server and documents it.",
+		Paths:   []string{"internal/app.go", "readme.md"},
+	}
+	messageTokens := core.EstimateTokenCount(commit.Subject + "\n" + commit.Body)
+
+	expected := messageTokens +
+		core.EstimateTokenCount("internal/app.go, ") +
+		core.EstimateTokenCount("readme.md, ")
+	if tokens := estimateMetadataTokens(commit); tokens != expected {
+		t.Errorf("Expected %d tokens for the message and file names, got %d", expected, tokens)
+	}
+
+	// Without changed paths only the message is counted
+	commit.Paths = nil
+	if tokens := estimateMetadataTokens(commit); tokens != messageTokens {
+		t.Errorf("Expected %d tokens for the message alone, got %d", messageTokens, tokens)
+	}
+}
+
+func TestMetadataOnlySelection(t *testing.T) {
+	repoPath, commit := createTestCommit(t)
+	m := &ListingModel{
+		BaseModel:       BaseModel{repoPath: repoPath, hashLength: core.DefaultHashLength},
+		commits:         []core.Commit{commit},
+		selectedCommits: map[int]bool{0: true},
+		metadataOnly:    map[string]bool{},
+	}
+
+	withDiff := m.calculateTokensForSelection()
+	m.metadataOnly[commit.Hash] = true
+	if tokens := m.calculateTokensForSelection(); tokens != estimateMetadataTokens(commit) || tokens >= withDiff {
+		t.Errorf("Expected the metadata estimate %d, below %d with the diff, got %d", estimateMetadataTokens(commit), withDiff, tokens)
+	}
+
+	data := buildChangelistData(repoPath, m.commits, m.selectedCommits, changelistOptions{
+		mode:         changelistCommits,
+		scope:        changelistScopeFull,
+		hashLength:   m.hashLength,
+		metadataOnly: m.metadataOnly,
+	})
+	if !strings.Contains(data, commit.Subject) || !strings.Contains(data, "internal/app.go") {
+		t.Errorf("Expected the message and file names in the changelist, got:\n%s", data)
+	}
+	if strings.Contains(data, "a line of changed code") {
+		t.Errorf("Expected no diff in the changelist, got:\n%s", data)
+	}
+}
@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"fmt"
+
+	"github.com/sarkarshuvojit/commitlore/internal/core/history"
 	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,6 +18,11 @@ const (
 	FormatSelectionView
 	ContentCreationView
 	ProviderView
+	FileView
+	HistoryView
+	BranchView
+	OutputsView
+	AnalysisView
 )
 
 // MessageType represents the type of message to display
@@ -35,17 +43,63 @@ type StatusMessage struct {
 
 // BaseModel contains common data needed by all models
 type BaseModel struct {
-	repoPath        string
-	llmProvider     llm.LLMProvider
-	llmProviderType string
-	statusMessage   *StatusMessage
-	errorMsg        string // Deprecated: use statusMessage instead
+	repoPath      string
+	llmProvider   llm.LLMProvider
+	statusMessage *StatusMessage
+	errorMsg      string // Deprecated: use statusMessage instead
+	advancedMode  bool   // Enables prompt previews, set via COMMITLORE_ADVANCED
+	hashLength    int    // Abbreviated commit hash length, see core.HashLength
+	history       *history.Store // Saved generations, nil unless COMMITLORE_HISTORY is set
+	revisionRange string         // Limits the listing to a revision range given on the command line
+	dryRun        bool           // Prompts are shown instead of sent, see dryRunProvider
+	mockMode      bool           // COMMITLORE_MOCK_MODE is set, so the mock provider is kept
+	layout        *layout        // Terminal size shared by all views, see layout
+}
+
+// providerName returns the display name of the active provider
+func (b BaseModel) providerName() string {
+	if b.llmProvider == nil {
+		return ""
+	}
+	provider, _ := b.llmProvider.ModelInfo()
+	return provider
+}
+
+// modelName returns the model the active provider uses, empty when unknown
+func (b BaseModel) modelName() string {
+	if b.llmProvider == nil {
+		return ""
+	}
+	_, model := b.llmProvider.ModelInfo()
+	return model
+}
+
+// maxTokens returns the active provider's cap on the length of a response,
+// llm.DefaultMaxTokens when it doesn't report one
+func (b BaseModel) maxTokens() int {
+	if reporter, ok := b.llmProvider.(llm.MaxTokensReporter); ok {
+		return reporter.MaxTokens()
+	}
+	return llm.DefaultMaxTokens
+}
+
+// providerStatus describes the active provider and, when known, its model
+func (b BaseModel) providerStatus() string {
+	if model := b.modelName(); model != "" {
+		return fmt.Sprintf("%s · %s", b.providerName(), model)
+	}
+	return b.providerName()
 }
 
 // AppModel is the main model that manages view state and delegation
 type AppModel struct {
 	BaseModel
 	currentView ViewState
+	showHelp    bool // Whether the '?' help overlay is open
+
+	// Terminal dimensions reported by tea.WindowSizeMsg
+	width  int
+	height int
 	
 	// Individual view models
 	splashModel    *SplashModel
@@ -54,11 +108,20 @@ type AppModel struct {
 	formatModel    *FormatModel
 	contentModel   *ContentModel
 	providerModel  *ProviderModel
+	fileModel      *FileModel
+	historyModel   *HistoryModel
+	branchModel    *BranchModel
+	outputsModel   *OutputsModel
+	analysisModel  *AnalysisModel
 	
 	// Shared data between views
 	selectedCommits map[int]bool
 	selectedTopic   string
-	selectedFormat  string
+	selectedFormats []string
+
+	// singleCommit is the commit given on the command line, which is analyzed
+	// on its own without going through the listing
+	singleCommit string
 }
 
 // Common messages used across views
@@ -68,8 +131,12 @@ type (
 	ErrorMsg       struct{ Error string }
 	SelectionMsg   struct{ Selection interface{} }
 	ProviderMsg    struct{}
+	FileMsg        struct{}
+	HistoryMsg     struct{}
+	BranchMsg      struct{}
+	OutputsMsg     struct{}
+	AnalyzeMsg     struct{}
 	flashTimerMsg  struct{}
-	splashTimerMsg struct{}
 )
 
 // ViewInterface defines the common interface for all view models
@@ -77,4 +144,10 @@ type ViewInterface interface {
 	Init() tea.Cmd
 	Update(msg tea.Msg) (tea.Model, tea.Cmd)
 	View() string
-}
\ No newline at end of file
+}
+
+// inputCapturer is implemented by views that can accept free text input.
+// While capturing, global shortcuts like 'q' are passed through to the view.
+type inputCapturer interface {
+	isCapturingInput() bool
+}
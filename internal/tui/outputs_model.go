@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
+)
+
+// OutputsModel handles the saved files view, listing content files saved
+// in the output directory with a viewer for their contents
+type OutputsModel struct {
+	BaseModel
+	dir         string
+	files       []core.OutputFile
+	cursor      int
+	viewport    int
+	maxViewport int
+	showDetail  bool
+	detail      viewport.Model
+}
+
+// NewOutputsModel creates a new saved files model
+func NewOutputsModel(base BaseModel) *OutputsModel {
+	return &OutputsModel{
+		BaseModel:   base,
+		maxViewport: 8,
+		detail:      viewport.New(94, 14),
+	}
+}
+
+// outputDir returns the directory content is saved to by default, see
+// core.OutputDir. If it can't be used the working directory is.
+func outputDir() string {
+	dir, err := core.OutputDir()
+	if err == nil {
+		return dir
+	}
+	core.GetLogger().Error("Failed to use the output directory, using the working directory", "error", err)
+	if dir, err = os.Getwd(); err != nil {
+		return "."
+	}
+	return dir
+}
+
+// outputSuffixes maps the filename suffix defaultFilename gives each format
+// to the format, so saved files can be recognized
+func outputSuffixes() map[string]string {
+	suffixes := make(map[string]string)
+	for _, format := range []string{ContentFormatBlogArticle, ContentFormatTwitterThread, ContentFormatLinkedInPost, ContentFormatTechnicalDocs} {
+		suffixes["_"+sanitizeFilename(format)+outputExtension(format)] = format
+	}
+	return suffixes
+}
+
+func (m *OutputsModel) Init() tea.Cmd {
+	m.showDetail = false
+	m.statusMessage = nil
+	m.loadFiles()
+	return nil
+}
+
+// loadFiles lists the saved files in the output directory
+func (m *OutputsModel) loadFiles() {
+	m.cursor = 0
+	m.viewport = 0
+	m.dir = outputDir()
+
+	files, err := core.ListOutputFiles(m.dir, outputSuffixes())
+	if err != nil {
+		core.GetLogger().Error("Failed to list saved files", "dir", m.dir, "error", err)
+		m.errorMsg = fmt.Sprintf("Failed to list saved files: %v", err)
+		m.files = nil
+		return
+	}
+	m.errorMsg = ""
+	m.files = files
+}
+
+func (m *OutputsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case editorClosedMsg:
+		if msg.Error != nil {
+			core.GetLogger().Error("Editor exited with an error", "error", msg.Error)
+			m.statusMessage = NewErrorMessage(fmt.Sprintf("Editor failed: %v", msg.Error))
+			return m, clearStatusAfterDelay()
+		}
+		// The file may have been edited, renamed or deleted
+		m.loadFiles()
+		return m, nil
+	case clearStatusMsg:
+		m.statusMessage = nil
+		return m, nil
+	case tea.KeyMsg:
+		if m.showDetail {
+			switch msg.String() {
+			case "esc":
+				m.showDetail = false
+				return m, nil
+			case "e":
+				return m, m.openInEditor()
+			}
+			var cmd tea.Cmd
+			m.detail, cmd = m.detail.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				if m.cursor < m.viewport {
+					m.viewport = m.cursor
+				}
+			}
+		case "down", "j":
+			if m.cursor < len(m.files)-1 {
+				m.cursor++
+				if m.cursor >= m.viewport+m.maxViewport {
+					m.viewport = m.cursor - m.maxViewport + 1
+				}
+			}
+		case "enter":
+			return m, m.openFile()
+		case "e":
+			return m, m.openInEditor()
+		case "r":
+			m.loadFiles()
+		case "esc":
+			return m, func() tea.Msg { return BackMsg{} }
+		}
+	}
+	return m, nil
+}
+
+// openFile shows the file under the cursor in the viewer
+func (m *OutputsModel) openFile() tea.Cmd {
+	if len(m.files) == 0 {
+		return nil
+	}
+	file := m.files[m.cursor]
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		core.GetLogger().Error("Failed to read saved file", "path", file.Path, "error", err)
+		m.statusMessage = NewErrorMessage(fmt.Sprintf("Failed to read %s: %v", file.Name, err))
+		return clearStatusAfterDelay()
+	}
+	m.detail.Width = m.layout.wrapWidth()
+	m.detail.SetContent(renderContent(string(data), m.layout.wrapWidth()))
+	m.detail.GotoTop()
+	m.showDetail = true
+	return nil
+}
+
+// openInEditor suspends the TUI and opens the file under the cursor in the
+// user's editor
+func (m *OutputsModel) openInEditor() tea.Cmd {
+	if len(m.files) == 0 {
+		return nil
+	}
+	file := m.files[m.cursor]
+
+	editor := editorCommand()
+	if editor == nil {
+		m.statusMessage = NewInfoMessage(fmt.Sprintf("No editor found, set $EDITOR. The file is at:\n%s", file.Path))
+		return clearStatusAfterDelay()
+	}
+
+	core.GetLogger().Info("Opening saved file in editor", "editor", editor[0], "path", file.Path)
+	cmd := exec.Command(editor[0], append(editor[1:], file.Path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorClosedMsg{Error: err}
+	})
+}
+
+func (m *OutputsModel) View() string {
+	header := titleStyle.Render("📂 Saved Files")
+	subtitle := subtitleStyle.Render(fmt.Sprintf("%d files in %s", len(m.files), m.dir))
+	if m.showDetail && len(m.files) > 0 {
+		file := m.files[m.cursor]
+		subtitle = subtitleStyle.Render(fmt.Sprintf("%s • %s • %s", file.Name, file.Format, file.ModTime.Format("Jan 02 2006, 15:04")))
+	}
+
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	var content string
+	var helpItems []string
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+	editHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("e"), helpDescStyle.Render("open in editor"))
+
+	switch {
+	case m.errorMsg != "":
+		content = errorStyle.Render(fmt.Sprintf("⚠ %s", m.errorMsg))
+		helpItems = []string{backHelp}
+	case m.showDetail:
+		content = commitRowStyle.Width(m.layout.boxWidth()).Padding(1).Render(m.detail.View())
+		scrollHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓"), helpDescStyle.Render("scroll"))
+		helpItems = []string{scrollHelp, " • ", editHelp, " • ", backHelp}
+	default:
+		content = m.renderList()
+		navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
+		openHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("view"))
+		refreshHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("r"), helpDescStyle.Render("refresh"))
+		helpItems = []string{navHelp, " • ", openHelp, " • ", editHelp, " • ", refreshHelp, " • ", backHelp}
+	}
+
+	if m.statusMessage != nil {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, RenderStatusMessage(m.statusMessage))
+	}
+
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
+	helpItems = append(helpItems, " • ", quitHelp)
+	statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, helpItems...))
+
+	main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar)
+	return appStyle.Render(main)
+}
+
+// renderList renders the visible page of saved files
+func (m *OutputsModel) renderList() string {
+	if len(m.files) == 0 {
+		empty := emptyStyle.Render("📭 No saved content yet. Press S after generating to save a file here")
+		return contentStyle.Width(m.layout.contentWidth()).Render(empty)
+	}
+
+	end := m.viewport + m.maxViewport
+	if end > len(m.files) {
+		end = len(m.files)
+	}
+
+	var rows []string
+	for i := m.viewport; i < end; i++ {
+		file := m.files[i]
+
+		name := truncateText(file.Name, 70)
+
+		cursor := "  "
+		nameText := subjectStyle.Render(name)
+		if i == m.cursor {
+			cursor = "▶ "
+			nameText = selectedSubjectStyle.Render(name)
+		}
+
+		firstLine := fmt.Sprintf("%s%s", cursor, nameText)
+		secondLine := fmt.Sprintf("  %s • %s • %s",
+			authorStyle.Render(file.Format),
+			dateStyle.Render(file.ModTime.Format("Jan 02, 15:04")),
+			dateStyle.Render(formatFileSize(file.Size)))
+		row := lipgloss.JoinVertical(lipgloss.Left, firstLine, secondLine)
+
+		if i == m.cursor {
+			row = selectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(row)
+		} else {
+			row = commitRowStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	return contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// formatFileSize renders a size in bytes as e.g. 820 B or 4.2 KB
+func formatFileSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
+
+// helpBindings lists the keys for browsing saved content files
+func (m *OutputsModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"enter", "view file"},
+		{"e", "open file in $EDITOR"},
+		{"r", "refresh the list"},
+		{"esc", "close file, or back"},
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -17,6 +18,17 @@ type ProviderModel struct {
 	providers      []config.Provider
 	providerConfig *config.ProviderConfig
 	loading        bool
+
+	// checking holds the IDs of providers whose availability check is still
+	// running. checkRound tells results of a stale refresh apart.
+	checking       map[string]bool
+	checkRound     int
+	hourglassFrame int
+
+	// activeModelChanged is set when the active provider's model was switched,
+	// so the provider is reloaded once on leaving the view rather than on
+	// every keypress
+	activeModelChanged bool
 }
 
 // NewProviderModel creates a new provider model
@@ -27,6 +39,7 @@ func NewProviderModel(base BaseModel) *ProviderModel {
 		providers:      []config.Provider{},
 		providerConfig: nil,
 		loading:        true,
+		checking:       make(map[string]bool),
 	}
 }
 
@@ -55,27 +68,62 @@ func (m *ProviderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			if len(m.providers) > 0 && m.cursor < len(m.providers) {
 				selectedProvider := m.providers[m.cursor]
+				if !config.IsImplemented(selectedProvider.ID) {
+					m.statusMessage = NewInfoMessage(fmt.Sprintf("%s isn't supported yet", selectedProvider.Name))
+					return m, clearStatusAfterDelay()
+				}
 				if selectedProvider.Enabled && selectedProvider.Available {
 					// Select this provider and go back
 					m.providerConfig.ActiveProviderID = selectedProvider.ID
+					m.activeModelChanged = false
 					return m, tea.Batch(
 						func() tea.Msg { return ProviderSelectedMsg{ProviderID: selectedProvider.ID} },
 						func() tea.Msg { return BackMsg{} },
 					)
 				}
 			}
+		case " ", "e":
+			return m, m.toggleEnabled()
+		case "left", "h":
+			return m, m.cycleModel(-1)
+		case "right", "l":
+			return m, m.cycleModel(1)
 		case "r":
 			// Refresh provider availability
 			return m, m.loadProviders
-		case "escape":
+		case "esc":
+			if m.activeModelChanged && m.providerConfig != nil {
+				m.activeModelChanged = false
+				id := m.providerConfig.ActiveProviderID
+				return m, tea.Batch(
+					func() tea.Msg { return ProviderSelectedMsg{ProviderID: id} },
+					func() tea.Msg { return BackMsg{} },
+				)
+			}
 			return m, func() tea.Msg { return BackMsg{} }
 		}
+	case clearStatusMsg:
+		m.statusMessage = nil
+		return m, nil
 	case providerLoadedMsg:
 		m.loading = false
 		m.providerConfig = msg.config
 		m.providers = msg.config.Providers
-		// Update availability
-		config.UpdateProviderAvailability(m.providerConfig)
+		return m, m.checkAvailability()
+	case providerAvailabilityMsg:
+		if msg.round != m.checkRound {
+			return m, nil
+		}
+		delete(m.checking, msg.providerID)
+		if provider := config.GetProviderByID(m.providerConfig, msg.providerID); provider != nil {
+			provider.Available = msg.available
+		}
+		return m, nil
+	case TickMsg:
+		if len(m.checking) > 0 {
+			m.hourglassFrame = (m.hourglassFrame + 1) % len(hourglassFrames)
+			return m, doTick()
+		}
 		return m, nil
 	case ErrorMsg:
 		m.loading = false
@@ -85,6 +133,65 @@ func (m *ProviderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleEnabled enables or disables the provider under the cursor and saves
+// the change to providers.json. The active provider can't be disabled, so
+// there is always one to fall back on, and providers the factory can't create
+// yet can't be enabled.
+func (m *ProviderModel) toggleEnabled() tea.Cmd {
+	if m.providerConfig == nil || m.cursor >= len(m.providers) {
+		return nil
+	}
+	provider := &m.providers[m.cursor]
+	if provider.Enabled && provider.ID == m.providerConfig.ActiveProviderID {
+		m.statusMessage = NewInfoMessage(fmt.Sprintf("%s is in use, switch to another provider before disabling it", provider.Name))
+		return clearStatusAfterDelay()
+	}
+	if !provider.Enabled && !config.IsImplemented(provider.ID) {
+		m.statusMessage = NewInfoMessage(fmt.Sprintf("%s isn't supported yet, so it can't be enabled", provider.Name))
+		return clearStatusAfterDelay()
+	}
+
+	provider.Enabled = !provider.Enabled
+	core.GetLogger().Info("Toggled provider", "provider_id", provider.ID, "enabled", provider.Enabled)
+	if err := config.SaveProviderConfig(m.providerConfig); err != nil {
+		core.GetLogger().Error("Failed to save provider config", "error", err)
+		m.statusMessage = NewErrorMessage(fmt.Sprintf("Failed to save: %v", err))
+		return clearStatusAfterDelay()
+	}
+
+	state := "Disabled"
+	if provider.Enabled {
+		state = "Enabled"
+	}
+	m.statusMessage = NewSuccessMessage(fmt.Sprintf("%s %s", state, provider.Name))
+	return clearStatusAfterDelay()
+}
+
+// cycleModel switches the provider under the cursor to the previous or next
+// model in its models list and saves it. Changing the active provider's model
+// reloads it when the view is left, so the next generation uses the new model.
+func (m *ProviderModel) cycleModel(step int) tea.Cmd {
+	if m.providerConfig == nil || m.cursor >= len(m.providers) {
+		return nil
+	}
+	provider := &m.providers[m.cursor]
+	if !provider.CycleModel(step) {
+		return nil
+	}
+
+	core.GetLogger().Info("Switched provider model", "provider_id", provider.ID, "model", provider.Config["model"])
+	if err := config.SaveProviderConfig(m.providerConfig); err != nil {
+		core.GetLogger().Error("Failed to save provider config", "error", err)
+		m.statusMessage = NewErrorMessage(fmt.Sprintf("Failed to save: %v", err))
+		return clearStatusAfterDelay()
+	}
+
+	if provider.ID == m.providerConfig.ActiveProviderID {
+		m.activeModelChanged = true
+	}
+	return nil
+}
+
 func (m *ProviderModel) View() string {
 	if m.errorMsg != "" {
 		return m.renderErrorState()
@@ -132,7 +239,7 @@ func (m *ProviderModel) renderErrorState() string {
 		"",
 		lipgloss.NewStyle().Foreground(lipgloss.Color("#64748b")).Render("Press 'esc' to go back"))
 
-	return lipgloss.Place(100, 30, lipgloss.Center, lipgloss.Center, errorCard.Render(errorContent))
+	return lipgloss.Place(m.layout.contentWidth(), 30, lipgloss.Center, lipgloss.Center, errorCard.Render(errorContent))
 }
 
 func (m *ProviderModel) renderLoadingState() string {
@@ -156,7 +263,7 @@ func (m *ProviderModel) renderLoadingState() string {
 	loadingContent := lipgloss.JoinVertical(lipgloss.Center,
 		lipgloss.JoinHorizontal(lipgloss.Left, spinner.Render(), " ", loadingText.Render()))
 
-	return lipgloss.Place(100, 30, lipgloss.Center, lipgloss.Center, loadingCard.Render(loadingContent))
+	return lipgloss.Place(m.layout.contentWidth(), 30, lipgloss.Center, lipgloss.Center, loadingCard.Render(loadingContent))
 }
 
 func (m *ProviderModel) renderEmptyState() string {
@@ -190,7 +297,7 @@ func (m *ProviderModel) renderEmptyState() string {
 		"",
 		lipgloss.NewStyle().Foreground(lipgloss.Color("#64748b")).Render("Press 'esc' to go back"))
 
-	return lipgloss.Place(100, 30, lipgloss.Center, lipgloss.Center, emptyCard.Render(emptyContent))
+	return lipgloss.Place(m.layout.contentWidth(), 30, lipgloss.Center, lipgloss.Center, emptyCard.Render(emptyContent))
 }
 
 func (m *ProviderModel) renderMainView() string {
@@ -201,7 +308,7 @@ func (m *ProviderModel) renderMainView() string {
 
 	mainContainer := lipgloss.NewStyle().
 		Padding(2, 4).
-		Width(96)
+		Width(m.layout.boxWidth())
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		header,
@@ -209,6 +316,9 @@ func (m *ProviderModel) renderMainView() string {
 		providerGrid,
 		"",
 		footer)
+	if m.statusMessage != nil {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, RenderStatusMessage(m.statusMessage))
+	}
 
 	return mainContainer.Render(content)
 }
@@ -334,13 +444,22 @@ func (m *ProviderModel) renderProviderCard(provider config.Provider, isSelected
 	}
 
 	description := provider.Description
-	if !provider.Enabled {
-		description = "Under development"
+
+	// The configured model, with arrows when it can be cycled
+	var modelLine string
+	if model := provider.Config["model"]; model != "" {
+		modelText := "Model: " + model
+		if isSelected && len(provider.Models()) > 1 {
+			modelText = fmt.Sprintf("Model: ◀ %s ▶", model)
+		}
+		modelLine = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8b5cf6")).
+			SetString(modelText).Render()
 	}
 
 	// Availability hint for unavailable providers
 	var availabilityHint string
-	if provider.Enabled && !provider.Available {
+	if provider.Enabled && !provider.Available && !m.checking[provider.ID] {
 		hintStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#f59e0b")).
 			Italic(true)
@@ -355,19 +474,18 @@ func (m *ProviderModel) renderProviderCard(provider config.Provider, isSelected
 		statusBadge)
 
 	// Card content assembly
-	var cardContent string
+	cardContent := lipgloss.JoinVertical(lipgloss.Left,
+		cardHeader,
+		"",
+		descStyle.Render(description))
+	if modelLine != "" {
+		cardContent = lipgloss.JoinVertical(lipgloss.Left, cardContent, modelLine)
+	}
 	if availabilityHint != "" {
 		cardContent = lipgloss.JoinVertical(lipgloss.Left,
-			cardHeader,
-			"",
-			descStyle.Render(description),
+			cardContent,
 			"",
 			availabilityHint)
-	} else {
-		cardContent = lipgloss.JoinVertical(lipgloss.Left,
-			cardHeader,
-			"",
-			descStyle.Render(description))
 	}
 
 	// Final card styling
@@ -377,11 +495,17 @@ func (m *ProviderModel) renderProviderCard(provider config.Provider, isSelected
 		Background(bgColor).
 		Padding(1, 2).
 		Margin(0, 0, 1, 0).
-		Width(84)
+		Width(m.cardWidth())
 
 	return cardStyle.Render(cardContent)
 }
 
+// cardWidth is the width of the provider cards and footer, inside the
+// padding of the main container
+func (m *ProviderModel) cardWidth() int {
+	return m.layout.boxWidth() - 12
+}
+
 func (m *ProviderModel) renderStatusBadge(provider config.Provider, isActive bool) string {
 	if isActive {
 		return lipgloss.NewStyle().
@@ -397,7 +521,14 @@ func (m *ProviderModel) renderStatusBadge(provider config.Provider, isActive boo
 			Foreground(lipgloss.Color("#ffffff")).
 			Background(lipgloss.Color("#64748b")).
 			Padding(0, 1).
-			SetString("BETA").Render()
+			SetString("DISABLED").Render()
+	}
+
+	if m.checking[provider.ID] {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#94a3b8")).
+			Padding(0, 1).
+			SetString(hourglassFrames[m.hourglassFrame] + " CHECKING").Render()
 	}
 
 	if !provider.Available {
@@ -423,6 +554,8 @@ func (m *ProviderModel) renderModernFooter() string {
 		shortcuts = append(shortcuts,
 			m.renderShortcut("↑↓", "navigate"),
 			m.renderShortcut("enter", "select"),
+			m.renderShortcut("←→", "model"),
+			m.renderShortcut("space", "enable/disable"),
 			m.renderShortcut("r", "refresh"))
 	}
 
@@ -444,12 +577,12 @@ func (m *ProviderModel) renderModernFooter() string {
 	// Create footer layout
 	footerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#64748b")).
-		Width(84)
+		Width(m.cardWidth())
 
 	if position != "" {
 		footer := lipgloss.JoinHorizontal(lipgloss.Left,
 			shortcutText,
-			strings.Repeat(" ", max(0, 84-lipgloss.Width(shortcutText)-lipgloss.Width(position))),
+			strings.Repeat(" ", max(0, m.cardWidth()-lipgloss.Width(shortcutText)-lipgloss.Width(position))),
 			position)
 		return footerStyle.Render(footer)
 	}
@@ -532,12 +665,55 @@ func (m *ProviderModel) loadProviders() tea.Msg {
 }
 
 
+// checkAvailability checks every provider concurrently, each reporting back
+// with a providerAvailabilityMsg when done
+func (m *ProviderModel) checkAvailability() tea.Cmd {
+	m.checkRound++
+	m.checking = make(map[string]bool)
+
+	cmds := []tea.Cmd{doTick()}
+	for _, provider := range m.providers {
+		provider := provider
+		round := m.checkRound
+		m.checking[provider.ID] = true
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), config.AvailabilityCheckTimeout)
+			defer cancel()
+			return providerAvailabilityMsg{
+				providerID: provider.ID,
+				available:  config.CheckProviderAvailabilityContext(ctx, &provider),
+				round:      round,
+			}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
 // Custom messages for provider management
 type providerLoadedMsg struct {
 	config *config.ProviderConfig
 }
 
+// providerAvailabilityMsg reports the result of one provider's availability check
+type providerAvailabilityMsg struct {
+	providerID string
+	available  bool
+	round      int
+}
+
 type ProviderSelectedMsg struct {
 	ProviderID string
 }
 
+// helpBindings lists the keys for choosing, enabling and configuring providers
+func (m *ProviderModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"g / G", "first / last provider"},
+		{"enter", "use provider"},
+		{"← → / h l", "previous / next model"},
+		{"space / e", "enable or disable provider"},
+		{"r", "refresh availability"},
+		{"esc", "back"},
+	}
+}
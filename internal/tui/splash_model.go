@@ -1,14 +1,35 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sarkarshuvojit/commitlore/internal/core"
 )
 
+// SplashSecondsEnvVar sets how many seconds the splash screen waits before
+// moving on to the commits. 0 waits for enter.
+const SplashSecondsEnvVar = "COMMITLORE_SPLASH_SECONDS"
+
+// defaultSplashSeconds is the splash countdown when SplashSecondsEnvVar is unset
+const defaultSplashSeconds = 3
+
+// splashTimerMsg counts down the splash screen one second at a time. round
+// tells ticks of an earlier countdown apart.
+type splashTimerMsg struct {
+	round int
+}
+
 type SplashModel struct {
 	BaseModel
+	// remaining is the number of seconds left before moving on, 0 once the
+	// countdown was stopped
+	remaining int
+	round     int
 }
 
 func NewSplashModel(base BaseModel) *SplashModel {
@@ -18,11 +39,42 @@ func NewSplashModel(base BaseModel) *SplashModel {
 }
 
 func (m *SplashModel) Init() tea.Cmd {
-	return tea.Tick(time.Second*3, func(t time.Time) tea.Msg {
-		return splashTimerMsg{}
+	m.round++
+	m.remaining = splashSeconds()
+	if m.remaining == 0 {
+		return nil
+	}
+	return m.tick()
+}
+
+// splashSeconds reads the splash countdown from SplashSecondsEnvVar
+func splashSeconds() int {
+	value, ok := os.LookupEnv(SplashSecondsEnvVar)
+	if !ok || value == "" {
+		return defaultSplashSeconds
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		core.GetLogger().Warn("Invalid splash countdown, using the default", "value", value, "default", defaultSplashSeconds)
+		return defaultSplashSeconds
+	}
+	return seconds
+}
+
+// tick waits a second of the countdown
+func (m *SplashModel) tick() tea.Cmd {
+	round := m.round
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return splashTimerMsg{round: round}
 	})
 }
 
+// stopCountdown keeps the splash screen up until a key is pressed
+func (m *SplashModel) stopCountdown() {
+	m.round++
+	m.remaining = 0
+}
+
 func (m *SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -31,9 +83,27 @@ func (m *SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg { return NextMsg{} }
 		case "p", "P":
 			return m, func() tea.Msg { return ProviderMsg{} }
+		case "f", "F":
+			return m, func() tea.Msg { return FileMsg{} }
+		case "h", "H":
+			return m, func() tea.Msg { return HistoryMsg{} }
+		case "b", "B":
+			return m, func() tea.Msg { return BranchMsg{} }
+		case "o", "O":
+			return m, func() tea.Msg { return OutputsMsg{} }
+		default:
+			// Any other key stops the countdown, to read the screen
+			m.stopCountdown()
 		}
 	case splashTimerMsg:
-		return m, func() tea.Msg { return NextMsg{} }
+		if msg.round != m.round || m.remaining == 0 {
+			return m, nil
+		}
+		m.remaining--
+		if m.remaining == 0 {
+			return m, func() tea.Msg { return NextMsg{} }
+		}
+		return m, m.tick()
 	}
 	return m, nil
 }
@@ -69,13 +139,28 @@ func (m *SplashModel) View() string {
 	content := strings.Join(centeredLines, "\n") + "\n\n" + centeredSubtitle
 	
 	// Add provider information
-	providerInfo := dimStyle.Render("Active Provider: " + m.llmProviderType)
+	providerInfo := dimStyle.Render("Active Provider: " + m.providerName())
 	
 	// Add keyboard shortcuts
-	shortcuts := dimStyle.Render("Press ENTER to continue • Press P for provider settings")
+	shortcuts := dimStyle.Render("Press ENTER to continue • Press P for provider settings • Press F for file history • Press B to pick a branch • Press H for history • Press O for saved files • Press ? for help")
 	
 	// Add some spacing and content
 	content += "\n\n" + providerInfo + "\n\n" + shortcuts
+	if m.remaining > 0 {
+		content += "\n\n" + dimStyle.Render(fmt.Sprintf("Continuing in %d… press any other key to stay", m.remaining))
+	}
 	
 	return appStyle.Render(content)
-}
\ No newline at end of file
+}
+
+// helpBindings lists the views that can be opened from the welcome screen
+func (m *SplashModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"enter / space", "browse commits"},
+		{"p", "choose LLM provider"},
+		{"f", "file history"},
+		{"b", "pick a branch"},
+		{"h", "saved generations"},
+		{"o", "saved content files"},
+	}
+}
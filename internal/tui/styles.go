@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 var (
@@ -140,13 +141,28 @@ var (
 			Bold(true)
 )
 
+// Diff stat styles
+var (
+	insertionStyle = lipgloss.NewStyle().
+			Foreground(successColor)
+	
+	deletionStyle = lipgloss.NewStyle().
+			Foreground(errorColor)
+	
+	expandedCommitStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(borderAccent).
+				MarginLeft(4).
+				PaddingLeft(1)
+)
+
 // Container styles
 var (
 	appStyle = lipgloss.NewStyle().
 			Padding(1, 2)
 	
-	contentStyle = lipgloss.NewStyle().
-			Width(100)
+	// Views set the width from their layout
+	contentStyle = lipgloss.NewStyle()
 	
 	scrollIndicatorStyle = lipgloss.NewStyle().
 				Foreground(textMuted).
@@ -244,4 +260,11 @@ func NewWarningMessage(content string) *StatusMessage {
 
 func NewInfoMessage(content string) *StatusMessage {
 	return &StatusMessage{Content: content, Type: MessageTypeInfo}
-}
\ No newline at end of file
+}
+
+// truncateText shortens text to at most width terminal cells, ending it with
+// "..." when it is cut. Wide runes such as CJK and emoji count as two cells,
+// and runes are never split.
+func truncateText(text string, width int) string {
+	return runewidth.Truncate(text, width, "...")
+}
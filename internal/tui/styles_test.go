@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected string
+	}{
+		{"Short text", "Retry logic", 70, "Retry logic"},
+		{"ASCII", strings.Repeat("a", 80), 70, strings.Repeat("a", 67) + "..."},
+		{"Accented", strings.Repeat("é", 80), 70, strings.Repeat("é", 67) + "..."},
+		{"Wide runes", strings.Repeat("日本", 40), 70, strings.Repeat("日本", 16) + "日..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.text, tt.width)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Expected valid UTF-8, got %q", got)
+			}
+			if runewidth.StringWidth(got) > tt.width {
+				t.Errorf("Expected at most %d cells, got %d", tt.width, runewidth.StringWidth(got))
+			}
+		})
+	}
+}
@@ -6,8 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 	"github.com/sarkarshuvojit/commitlore/internal/core/llm"
 )
@@ -15,30 +20,108 @@ import (
 // TopicModel handles the topic selection view
 type TopicModel struct {
 	BaseModel
-	topics        []string
-	cursor        int
-	selectedTopic string
-	asyncWrapper  *llm.AsyncLLMWrapper
-	isExtracting  bool
+	topics              []string
+	cursor              int
+	selectedTopic       string
+	isExtracting        bool
+	cancelExtraction    context.CancelFunc // Cancels the in-flight extraction, set while extracting
+	extractingWith      string             // Provider and model running the in-flight extraction
 	extractionStartTime time.Time
-	hourglassFrame int
+	hourglassFrame      int
+
+	// Prompt preview state, only used in advanced mode
+	isPreviewingPrompt bool
+	systemPromptInput  textarea.Model
+	userPromptInput    textarea.Model
+	promptFocus        int
+
+	// changelistMode controls how the selected commits are turned into a changelist
+	changelistMode changelistMode
+	// metadataOnly holds the hashes of commits sent without their diff
+	metadataOnly map[string]bool
+
+	// Commits being analysed, kept so extraction can be rerun with 'r' or
+	// retried with a reduced changelistScope after a context length error
+	commits         []core.Commit
+	selectedCommits map[int]bool
+	changelistScope changelistScope
+	canReduceScope  bool
+
+	// Extraction prompt shown in place of topics during a dry run
+	dryRunPrompt viewport.Model
+
+	// focus is a hint typed after pressing f, e.g. "emphasize performance",
+	// that steers extraction towards an angle. It is kept for later
+	// extractions until it is cleared by entering an empty one.
+	focus          string
+	focusInput     textinput.Model
+	isSettingFocus bool
 }
 
+// dryRunTopic stands in for extracted topics during a dry run so content
+// generation can still be walked through
+const dryRunTopic = "Dry run topic (no LLM was called)"
+
+// Prompt preview focus targets
+const (
+	promptFocusSystem = iota
+	promptFocusUser
+)
+
+// topicExtractionSystemPrompt is the system prompt used for topic extraction
+const topicExtractionSystemPrompt = `You are a developer story assistant. Your task is to analyze commit changesets including diffs and extract meaningful topics that could be used for creating developer content like blog posts, social media posts, or technical articles.
+
+Analyze the provided commits with their full changesets and extract 3-5 relevant topics that would be interesting for developer content creation. Focus on:
+- Technical concepts and implementations revealed in the code changes
+- Problem-solving approaches shown in the diffs
+- Development practices and patterns demonstrated
+- Technology stack and tools used
+- Performance improvements or optimizations
+- Architectural decisions and refactoring patterns
+- Bug fixes and their underlying issues
+
+Return only the topic titles, one per line, with no numbering, bullets, additional text or explanations.`
+
+// extractionTimeout bounds how long topic extraction may run
+const extractionTimeout = 2 * time.Minute
+
 // NewTopicModel creates a new topic model
 func NewTopicModel(base BaseModel) *TopicModel {
-	// Create async wrapper with 60 second timeout
-	var asyncWrapper *llm.AsyncLLMWrapper
-	if base.llmProvider != nil {
-		asyncWrapper = llm.NewAsyncLLMWrapper(base.llmProvider, 120*time.Second)
+	fi := textinput.New()
+	fi.Placeholder = "e.g. emphasize performance, empty for no focus"
+	fi.Prompt = "🎯 "
+
+	m := &TopicModel{
+		BaseModel:         base,
+		topics:            []string{},
+		cursor:            0,
+		isExtracting:      false,
+		systemPromptInput: newPromptTextarea(),
+		userPromptInput:   newPromptTextarea(),
+		dryRunPrompt:      viewport.New(base.layout.boxWidth(), 12),
+		focusInput:        fi,
 	}
+	m.resizeInputs()
+	return m
+}
 
-	return &TopicModel{
-		BaseModel:    base,
-		topics:       []string{},
-		cursor:       0,
-		asyncWrapper: asyncWrapper,
-		isExtracting: false,
-	}
+// resizeInputs sizes the focus input and the prompt textareas to the layout
+func (m *TopicModel) resizeInputs() {
+	m.focusInput.Width = m.layout.wrapWidth() - 14
+	m.systemPromptInput.SetWidth(m.layout.wrapWidth())
+	m.userPromptInput.SetWidth(m.layout.wrapWidth())
+}
+
+// newPromptTextarea creates a textarea for editing a prompt in the preview
+func newPromptTextarea() textarea.Model {
+	ta := textarea.New()
+	ta.SetHeight(6)
+	ta.CharLimit = 0
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	// Plain enter starts the extraction, so new lines take a modifier
+	ta.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("shift+enter", "alt+enter"))
+	return ta
 }
 
 func (m *TopicModel) Init() tea.Cmd {
@@ -47,6 +130,9 @@ func (m *TopicModel) Init() tea.Cmd {
 
 func (m *TopicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resizeInputs()
+		return m, nil
 	case TickMsg:
 		if m.isExtracting {
 			m.hourglassFrame = (m.hourglassFrame + 1) % 4
@@ -54,26 +140,104 @@ func (m *TopicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case llm.LLMResponseMsg:
+		// Responses to canceled requests were already dealt with on esc
+		if msg.Canceled {
+			return m, nil
+		}
 		m.isExtracting = false
+		if m.cancelExtraction != nil {
+			m.cancelExtraction()
+			m.cancelExtraction = nil
+		}
 		if msg.Error != "" {
 			m.errorMsg = msg.Error
 			m.topics = []string{}
+			m.canReduceScope = msg.ContextLengthExceeded && m.changelistScope < changelistScopeMessagesOnly
 		} else {
 			m.errorMsg = ""
-			// Parse topics from response (assuming comma-separated)
-			topics := strings.Split(msg.Content, ",")
-			for i, topic := range topics {
-				topics[i] = strings.TrimSpace(topic)
+			if m.dryRun {
+				m.dryRunPrompt.Width = m.layout.boxWidth()
+				m.dryRunPrompt.SetContent(wordwrap.String(msg.Content, m.layout.wrapWidth()))
+				m.dryRunPrompt.GotoTop()
+				m.SetTopics([]string{dryRunTopic})
+				return m, nil
+			}
+			topics := llm.ParseTopicsFromResponse(msg.Content)
+			if len(topics) == 0 {
+				core.GetLogger().Warn("No topics found in the LLM response", "response", core.LogExcerpt(msg.Content))
+				m.errorMsg = "No topics found in the LLM response"
 			}
 			m.SetTopics(topics)
 		}
 		return m, nil
 	case tea.KeyMsg:
-		// Don't allow input while extracting topics
+		// Only esc, to cancel and go back, is handled while extracting topics
 		if m.isExtracting {
+			if msg.String() == "esc" {
+				core.GetLogger().Info("Canceling topic extraction", "elapsed", m.getElapsedTime())
+				if m.cancelExtraction != nil {
+					m.cancelExtraction()
+					m.cancelExtraction = nil
+				}
+				m.isExtracting = false
+				return m, func() tea.Msg { return BackMsg{} }
+			}
 			return m, nil
 		}
 
+		if m.isPreviewingPrompt {
+			return m.updatePromptPreview(msg)
+		}
+		if m.isSettingFocus {
+			return m.updateFocusInput(msg)
+		}
+
+		// The prompt was too large to send: retry with less detail, or go
+		// back to change the selection
+		if m.statusMessage != nil {
+			switch msg.String() {
+			case "r":
+				if m.canReduceScope {
+					m.changelistScope++
+					core.GetLogger().Info("Retrying topic extraction with reduced scope", "scope", m.changelistScope.String())
+					return m, m.extract()
+				}
+			case "esc":
+				m.statusMessage = nil
+				return m, func() tea.Msg { return BackMsg{} }
+			}
+			return m, nil
+		}
+
+		if m.errorMsg != "" {
+			switch msg.String() {
+			case "r":
+				if m.canReduceScope {
+					m.changelistScope++
+					core.GetLogger().Info("Retrying topic extraction with reduced scope", "scope", m.changelistScope.String())
+					return m, m.extract()
+				}
+				if len(m.selectedCommits) > 0 {
+					core.GetLogger().Info("Retrying topic extraction")
+					return m, m.extract()
+				}
+			case "esc":
+				return m, func() tea.Msg { return BackMsg{} }
+			}
+			return m, nil
+		}
+
+		if m.dryRun {
+			switch msg.String() {
+			case "enter", "esc", "f":
+			default:
+				// The only topic is a placeholder, so keys scroll the prompt instead
+				var cmd tea.Cmd
+				m.dryRunPrompt, cmd = m.dryRunPrompt.Update(msg)
+				return m, cmd
+			}
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -94,31 +258,115 @@ func (m *TopicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedTopic = m.topics[m.cursor]
 				return m, func() tea.Msg { return NextMsg{} }
 			}
-		case "escape":
+		case "r":
+			// Extract fresh topics from the same commits
+			if len(m.selectedCommits) > 0 {
+				core.GetLogger().Info("Re-extracting topics", "selected_commits", len(m.selectedCommits))
+				return m, m.extract()
+			}
+		case "f":
+			// Steer a fresh extraction with a focus hint
+			if len(m.selectedCommits) > 0 {
+				m.isSettingFocus = true
+				m.focusInput.SetValue(m.focus)
+				m.focusInput.CursorEnd()
+				return m, m.focusInput.Focus()
+			}
+		case "esc":
 			return m, func() tea.Msg { return BackMsg{} }
 		}
 	}
 	return m, nil
 }
 
+// updateFocusInput handles keys while the focus hint is being typed. Enter
+// extracts fresh topics with the new focus.
+func (m *TopicModel) updateFocusInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.isSettingFocus = false
+		m.focusInput.Blur()
+		m.focus = strings.TrimSpace(m.focusInput.Value())
+		core.GetLogger().Info("Re-extracting topics with focus", "focus", m.focus)
+		return m, m.extract()
+	case "esc":
+		m.isSettingFocus = false
+		m.focusInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.focusInput, cmd = m.focusInput.Update(msg)
+	return m, cmd
+}
+
+// updatePromptPreview handles input while the extraction prompt is being reviewed
+func (m *TopicModel) updatePromptPreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Plain enter extracts, shift+enter and alt+enter reach the focused prompt
+	switch msg.String() {
+	case "enter":
+		m.isPreviewingPrompt = false
+		return m, m.startExtraction(m.systemPromptInput.Value(), m.userPromptInput.Value())
+	case "tab":
+		if m.promptFocus == promptFocusSystem {
+			m.promptFocus = promptFocusUser
+			m.systemPromptInput.Blur()
+			return m, m.userPromptInput.Focus()
+		}
+		m.promptFocus = promptFocusSystem
+		m.userPromptInput.Blur()
+		return m, m.systemPromptInput.Focus()
+	case "esc":
+		m.isPreviewingPrompt = false
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+
+	var cmd tea.Cmd
+	if m.promptFocus == promptFocusSystem {
+		m.systemPromptInput, cmd = m.systemPromptInput.Update(msg)
+	} else {
+		m.userPromptInput, cmd = m.userPromptInput.Update(msg)
+	}
+	return m, cmd
+}
+
 func (m *TopicModel) View() string {
 	if m.errorMsg != "" {
 		errorContent := errorStyle.Render(fmt.Sprintf("⚠ Error: %s", m.errorMsg))
 		helpText := helpDescStyle.Render("Press 'q' or Ctrl+C to quit • 'esc' to go back")
+		if m.canReduceScope {
+			helpText = lipgloss.JoinVertical(lipgloss.Left, scopeRetryHelp(m.changelistScope), helpText)
+		} else if len(m.selectedCommits) > 0 {
+			helpText = helpDescStyle.Render("Press 'r' to try again • 'q' or Ctrl+C to quit • 'esc' to go back")
+		}
 		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, errorContent, helpText))
 	}
 
+	if m.statusMessage != nil {
+		statusContent := RenderStatusMessage(m.statusMessage)
+		helpText := helpDescStyle.Render("Press 'q' or Ctrl+C to quit • 'esc' to go back")
+		if m.canReduceScope {
+			helpText = lipgloss.JoinVertical(lipgloss.Left, scopeRetryHelp(m.changelistScope), helpText)
+		}
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, statusContent, helpText))
+	}
+
+	if m.isPreviewingPrompt {
+		return m.renderPromptPreview()
+	}
+
 	if m.isExtracting {
 		header := titleStyle.Render("📝 Extracting Topics")
 		hourglass := m.getHourglassFrame()
 		elapsedTime := m.getElapsedTime()
 		subtitle := subtitleStyle.Render(fmt.Sprintf("🤖 Analyzing commits with AI... %s (%s)", hourglass, elapsedTime))
 		headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
-		headerWithBg := headerStyle.Width(100).Align(lipgloss.Left).Render(headerContent)
+		headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
 
-		generatingHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render(hourglass), helpDescStyle.Render("extracting topics..."))
+		generatingHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render(hourglass), helpDescStyle.Render(fmt.Sprintf("extracting topics with %s...", m.extractingWith)))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
 		quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
-		helpText := lipgloss.JoinHorizontal(lipgloss.Left, generatingHelp, " • ", quitHelp)
+		helpText := lipgloss.JoinHorizontal(lipgloss.Left, generatingHelp, " • ", cancelHelp, " • ", quitHelp)
 		statusBar := statusBarStyle.Render(helpText)
 
 		main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, statusBar)
@@ -127,9 +375,12 @@ func (m *TopicModel) View() string {
 
 	header := titleStyle.Render("📝 Select Topic for Content Creation")
 	subtitle := subtitleStyle.Render(fmt.Sprintf("Choose from %d extracted topics", len(m.topics)))
+	if m.focus != "" {
+		subtitle = subtitleStyle.Render(fmt.Sprintf("Choose from %d extracted topics • 🎯 Focus: %s", len(m.topics), m.focus))
+	}
 
 	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
-	headerWithBg := headerStyle.Width(100).Align(lipgloss.Left).Render(headerContent)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
 
 	var topicRows []string
 	for i, topic := range m.topics {
@@ -150,7 +401,7 @@ func (m *TopicModel) View() string {
 		row := fmt.Sprintf("%s%s", cursor, topicText)
 
 		if isSelected {
-			row = selectedCommitRowStyle.Width(96).Align(lipgloss.Left).Render(row)
+			row = selectedCommitRowStyle.Width(m.layout.boxWidth()).Align(lipgloss.Left).Render(row)
 		} else {
 			row = commitRowStyle.Render(row)
 		}
@@ -158,17 +409,35 @@ func (m *TopicModel) View() string {
 		topicRows = append(topicRows, row)
 	}
 
-	content := contentStyle.Render(lipgloss.JoinVertical(lipgloss.Left, topicRows...))
+	content := contentStyle.Width(m.layout.contentWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, topicRows...))
+	if m.dryRun {
+		promptTitle := subjectStyle.Render("🧪 Extraction Prompt (dry run, nothing was sent)")
+		promptBox := commitRowStyle.Width(m.layout.boxWidth()).Padding(1).Render(m.dryRunPrompt.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, promptTitle, promptBox, content)
+	}
+
+	if m.isSettingFocus {
+		inputBox := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Render(m.focusInput.View())
+		content = lipgloss.JoinVertical(lipgloss.Left, content, inputBox)
+		extractHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("extract with focus"))
+		cancelHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("cancel"))
+		statusBar := statusBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, extractHelp, " • ", cancelHelp))
+		return appStyle.Render(lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar))
+	}
 
 	navHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("↑↓/jk"), helpDescStyle.Render("navigate"))
 	selectHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("select"))
+	refreshHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("r"), helpDescStyle.Render("new topics"))
+	focusHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("f"), helpDescStyle.Render("focus"))
 	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
 	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("q"), helpDescStyle.Render("quit"))
 
 	position := positionStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.topics)))
-	providerInfo := positionStyle.Render(fmt.Sprintf("Provider: %s", m.llmProviderType))
+	providerInfo := positionStyle.Render(fmt.Sprintf("Provider: %s", m.providerName()))
 
-	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", selectHelp, " • ", backHelp, " • ", quitHelp)
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, navHelp, " • ", selectHelp, " • ", refreshHelp, " • ", focusHelp, " • ", backHelp, " • ", quitHelp)
 	statusContent := lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		helpText,
@@ -183,108 +452,164 @@ func (m *TopicModel) View() string {
 	return appStyle.Render(main)
 }
 
+// isCapturingInput reports whether the prompt preview editor or the focus
+// input has focus
+func (m *TopicModel) isCapturingInput() bool {
+	return m.isPreviewingPrompt || m.isSettingFocus
+}
+
+// renderPromptPreview renders the editable system and user prompts used for extraction
+func (m *TopicModel) renderPromptPreview() string {
+	header := titleStyle.Render("🔍 Review Extraction Prompt")
+	subtitle := subtitleStyle.Render("Edit the prompts below before they are sent to the AI")
+	headerContent := lipgloss.JoinVertical(lipgloss.Left, header, subtitle)
+	headerWithBg := headerStyle.Width(m.layout.contentWidth()).Align(lipgloss.Left).Render(headerContent)
+
+	renderBox := func(title string, input textarea.Model, focused bool) string {
+		titleText := subjectStyle.Render(title)
+		if focused {
+			titleText = selectedSubjectStyle.Render("▶ " + title)
+		}
+		box := commitRowStyle.
+			Width(m.layout.boxWidth()).
+			Padding(0, 1).
+			Render(input.View())
+		return lipgloss.JoinVertical(lipgloss.Left, titleText, box)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		renderBox("🧠 System Prompt", m.systemPromptInput, m.promptFocus == promptFocusSystem),
+		renderBox("📝 User Prompt", m.userPromptInput, m.promptFocus == promptFocusUser))
+
+	switchHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("tab"), helpDescStyle.Render("switch prompt"))
+	extractHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("enter"), helpDescStyle.Render("extract topics"))
+	newlineHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("alt+enter"), helpDescStyle.Render("new line"))
+	backHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("esc"), helpDescStyle.Render("back"))
+	quitHelp := fmt.Sprintf("%s %s", helpKeyStyle.Render("ctrl+c"), helpDescStyle.Render("quit"))
+	helpText := lipgloss.JoinHorizontal(lipgloss.Left, switchHelp, " • ", extractHelp, " • ", newlineHelp, " • ", backHelp, " • ", quitHelp)
+	statusBar := statusBarStyle.Render(helpText)
+
+	main := lipgloss.JoinVertical(lipgloss.Left, headerWithBg, content, statusBar)
+	return appStyle.Render(main)
+}
+
 // SetTopics sets the topics for the model
 func (m *TopicModel) SetTopics(topics []string) {
 	m.topics = topics
 	m.cursor = 0
 }
 
+// SetChangelistMode sets how the selected commits are turned into a changelist
+func (m *TopicModel) SetChangelistMode(mode changelistMode) {
+	m.changelistMode = mode
+}
+
+// SetMetadataOnly sets the commits, by hash, that are sent without their diff
+func (m *TopicModel) SetMetadataOnly(hashes map[string]bool) {
+	m.metadataOnly = hashes
+}
+
 // GetSelectedTopic returns the selected topic
 func (m *TopicModel) GetSelectedTopic() string {
 	return m.selectedTopic
 }
 
-// ExtractTopics extracts topics from selected commits using async LLM calls
+// ExtractTopics extracts topics from selected commits using async LLM calls.
+// In advanced mode the assembled prompts are shown for review first and the
+// extraction starts once the user confirms them.
 func (m *TopicModel) ExtractTopics(commits []core.Commit, selectedCommits map[int]bool) tea.Cmd {
+	m.commits = commits
+	m.selectedCommits = selectedCommits
+	m.changelistScope = changelistScopeFull
+	return m.extract()
+}
+
+// extract builds the prompts for the stored commits at the current scope and
+// starts extraction, or shows the prompt preview in advanced mode
+func (m *TopicModel) extract() tea.Cmd {
 	logger := core.GetLogger()
-	logger.Info("Starting topic extraction", "selected_commits", len(selectedCommits), "provider", m.llmProviderType)
+	logger.Info("Starting topic extraction", "selected_commits", len(m.selectedCommits), "scope", m.changelistScope.String(), "provider", m.providerName())
 
-	if m.asyncWrapper == nil {
+	if m.llmProvider == nil {
 		m.errorMsg = "LLM provider not configured"
-		logger.Error("LLM provider not configured for topic extraction", "provider", m.llmProviderType)
+		logger.Error("LLM provider not configured for topic extraction", "provider", m.providerName())
 		return nil
 	}
 
-	m.isExtracting = true
 	m.errorMsg = ""
+	m.statusMessage = nil
+	m.canReduceScope = false
 	m.topics = []string{}
-	m.extractionStartTime = time.Now()
-	m.hourglassFrame = 0
 
-	// Create channel for async response
-	responseChan := llm.CreateLLMResponseChannel()
+	systemPrompt, userPrompt := m.buildExtractionPrompts()
 
-	// Get selected commit data
-	var selectedCommitHashes []string
-	for index := range selectedCommits {
-		if index < len(commits) {
-			selectedCommitHashes = append(selectedCommitHashes, commits[index].Hash)
-		}
+	if m.advancedMode {
+		logger.Debug("Showing extraction prompt preview", "system_prompt_length", len(systemPrompt), "user_prompt_length", len(userPrompt))
+		m.isPreviewingPrompt = true
+		m.promptFocus = promptFocusUser
+		m.systemPromptInput.SetValue(systemPrompt)
+		m.systemPromptInput.Blur()
+		m.userPromptInput.SetValue(userPrompt)
+		return m.userPromptInput.Focus()
 	}
 
+	return m.startExtraction(systemPrompt, userPrompt)
+}
+
+// buildExtractionPrompts assembles the system and user prompts for topic extraction
+func (m *TopicModel) buildExtractionPrompts() (string, string) {
 	// Build comprehensive changelist data for topic extraction
-	var commitDetails []string
-	for index := range selectedCommits {
-		if index < len(commits) {
-			commit := commits[index]
-			
-			// Get changelist data for this commit
-			changeset, err := core.GetChangesForCommit(m.repoPath, commit.Hash)
-			if err != nil {
-				logger.Error("Failed to get changeset for commit", "hash", commit.Hash, "error", err, "provider", m.llmProviderType)
-				// Fall back to basic commit info
-				detail := fmt.Sprintf("- %s: %s", commit.Hash[:8], commit.Subject)
-				commitDetails = append(commitDetails, detail)
-				continue
-			}
+	changelistData := buildChangelistData(m.repoPath, m.commits, m.selectedCommits, changelistOptions{
+		mode:         m.changelistMode,
+		scope:        m.changelistScope,
+		hashLength:   m.hashLength,
+		metadataOnly: m.metadataOnly,
+	})
+	// Knowing the languages involved helps the LLM pick relevant topics
+	if languages := languageSummary(m.commits, m.selectedCommits, m.changelistMode); languages != "" {
+		changelistData = languages + "\n\n" + changelistData
+	}
+
+	userPrompt := fmt.Sprintf(`Analyze these commits with their full changesets and extract meaningful topics for content creation:
 
-			// Create detailed commit information with changelist
-			detail := fmt.Sprintf(`Commit: %s
-Author: %s
-Date: %s  
-Subject: %s
-Body: %s
-Files Changed: %s
-Diff:
 %s
 
----`, 
-				commit.Hash[:8], 
-				changeset.Author, 
-				changeset.Date.Format("2006-01-02 15:04:05"),
-				changeset.Subject,
-				changeset.Body,
-				strings.Join(changeset.Files, ", "),
-				changeset.Diff)
-			
-			commitDetails = append(commitDetails, detail)
-		}
+Provide 3-5 topics, one per line.`, changelistData)
+	if m.focus != "" {
+		userPrompt += fmt.Sprintf("\n\nSteer the topics towards this focus: %s", m.focus)
 	}
 
-	systemPrompt := `You are a developer story assistant. Your task is to analyze commit changesets including diffs and extract meaningful topics that could be used for creating developer content like blog posts, social media posts, or technical articles.
-
-Analyze the provided commits with their full changesets and extract 3-5 relevant topics that would be interesting for developer content creation. Focus on:
-- Technical concepts and implementations revealed in the code changes
-- Problem-solving approaches shown in the diffs
-- Development practices and patterns demonstrated
-- Technology stack and tools used
-- Performance improvements or optimizations
-- Architectural decisions and refactoring patterns
-- Bug fixes and their underlying issues
+	return topicExtractionSystemPrompt, userPrompt
+}
 
-Return only the topics as a comma-separated list, with no additional text or explanations.`
+// startExtraction fires the async LLM call with the given prompts
+func (m *TopicModel) startExtraction(systemPrompt, userPrompt string) tea.Cmd {
+	logger := core.GetLogger()
 
-	userPrompt := fmt.Sprintf(`Analyze these commits with their full changesets and extract meaningful topics for content creation:
+	promptTokens := core.EstimateTokenCount(systemPrompt) + core.EstimateTokenCount(userPrompt)
+	if err := llm.CheckPromptSize(m.providerName(), m.modelName(), promptTokens, m.maxTokens()); err != nil {
+		logger.Warn("Not sending a prompt larger than the context window", "prompt_tokens", promptTokens, "error", err)
+		m.canReduceScope = m.changelistScope < changelistScopeMessagesOnly
+		m.statusMessage = NewWarningMessage(promptTooLargeMessage(err))
+		return nil
+	}
 
-%s
+	m.isExtracting = true
+	m.errorMsg = ""
+	m.topics = []string{}
+	m.extractionStartTime = time.Now()
+	m.hourglassFrame = 0
 
-Provide 3-5 topics as a comma-separated list.`, strings.Join(commitDetails, "\n"))
+	// Create channel for async response
+	responseChan := llm.CreateLLMResponseChannel()
 
 	// Start async LLM call
-	ctx := context.Background()
-	m.asyncWrapper.GenerateContentWithSystemPromptAsync(ctx, systemPrompt, userPrompt, responseChan)
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelExtraction = cancel
+	m.extractingWith = m.providerStatus()
+	llm.NewAsyncLLMWrapper(m.llmProvider, extractionTimeout).GenerateContentWithSystemPromptAsync(ctx, systemPrompt, userPrompt, responseChan)
 
-	logger.Info("Started async LLM call for topic extraction", "provider", m.llmProviderType)
+	logger.Info("Started async LLM call for topic extraction", "provider", m.providerName())
 
 	// Return command to wait for response
 	return tea.Batch(llm.WaitForLLMResponse(responseChan), doTick())
@@ -313,3 +638,17 @@ func (m *TopicModel) getElapsedTime() string {
 		return fmt.Sprintf("%dm %ds", minutes, seconds)
 	}
 }
+
+// helpBindings lists the keys for choosing a topic and re-extracting topics
+func (m *TopicModel) helpBindings() []helpBinding {
+	return []helpBinding{
+		{"↑↓ / j k", "move"},
+		{"g / G", "first / last topic"},
+		{"enter", "choose topic"},
+		{"r", "extract fresh topics, or retry after an error"},
+		{"f", "extract fresh topics with a focus, e.g. performance"},
+		{"↑↓ / pgup pgdn", "scroll the prompt during a dry run"},
+		{"shift+enter / alt+enter", "new line while reviewing the prompt"},
+		{"esc", "cancel extraction, or go back to commits"},
+	}
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPromptPreviewKeys(t *testing.T) {
+	m := NewTopicModel(BaseModel{llmProvider: &namedProvider{name: "preview"}, layout: &layout{}})
+	m.isPreviewingPrompt = true
+	m.promptFocus = promptFocusUser
+	m.userPromptInput.SetValue("List the topics")
+	m.userPromptInput.Focus()
+
+	// alt+enter adds a new line to the focused prompt
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Be brief")})
+	if value := m.userPromptInput.Value(); value != "List the topics\nBe brief" {
+		t.Errorf("Expected alt+enter to add a new line, got %q", value)
+	}
+	if !m.isPreviewingPrompt || m.isExtracting {
+		t.Fatal("Expected alt+enter to keep the preview open")
+	}
+
+	// Plain enter extracts with the edited prompt
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.isPreviewingPrompt || !m.isExtracting {
+		t.Fatalf("Expected enter to start extraction, got error %q", m.errorMsg)
+	}
+}
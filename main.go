@@ -1,14 +1,64 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"runtime"
+	"runtime/debug"
 
 	"github.com/sarkarshuvojit/commitlore/internal/core"
 	"github.com/sarkarshuvojit/commitlore/internal/tui"
 )
 
+// Build information, set with -ldflags "-X main.version=... -X main.commit=..."
+var (
+	version = "dev"
+	commit  = ""
+)
+
+// versionString describes the build: its version, the commit it was built
+// from and the Go version. Without an injected commit the VCS revision Go
+// embeds at build time is used.
+func versionString() string {
+	revision := commit
+	if revision == "" {
+		revision = "unknown"
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					revision = setting.Value
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("commitlore %s (commit %s, %s)", version, revision, runtime.Version())
+}
+
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: commitlore [flags] [revision-range]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Browse the commits in revision-range (for example HEAD~10..HEAD, or main for its history), or all of HEAD when omitted.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "With --commit, go straight to extracting topics from a single commit.\n\n")
+		flag.PrintDefaults()
+	}
+	dryRun := flag.Bool("dry-run", false, "show the assembled prompts instead of sending them to an LLM")
+	commitArg := flag.String("commit", "", "extract topics from a single commit, e.g. a1b2c3d or v1.2.0, skipping the commit list")
+	var showVersion bool
+	flag.BoolVar(&showVersion, "version", false, "print version information and exit")
+	flag.BoolVar(&showVersion, "v", false, "print version information and exit (shorthand)")
+	flag.Parse()
+	if showVersion {
+		fmt.Println(versionString())
+		return
+	}
+	if flag.NArg() > 1 || (flag.NArg() > 0 && *commitArg != "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	revisionRange := flag.Arg(0)
+	var singleCommit string
+
 	if err := core.InitLogger(); err != nil {
 		fmt.Printf("Error initializing logger: %v\n", err)
 		os.Exit(1)
@@ -17,6 +67,12 @@ func main() {
 	logger := core.GetLogger()
 	logger.Info("CommitLore application starting")
 	
+	if err := core.CheckGitInstalled(); err != nil {
+		logger.Error("Git not found", "error", err)
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
 	cwd, err := os.Getwd()
 	if err != nil {
 		logger.Error("Error getting current directory", "error", err)
@@ -24,7 +80,7 @@ func main() {
 		os.Exit(1)
 	}
 	
-	_, isGitRepo, err := core.GetGitDirectory(cwd)
+	repoRoot, isGitRepo, err := core.GetGitDirectory(cwd)
 	if err != nil {
 		logger.Error("Error checking Git repository", "error", err)
 		fmt.Printf("Error checking Git repository: %v\n", err)
@@ -37,8 +93,24 @@ func main() {
 		os.Exit(1)
 	}
 	
+	if *commitArg != "" {
+		hash, err := core.ResolveCommit(repoRoot, *commitArg)
+		if err != nil {
+			logger.Error("Invalid commit", "commit", *commitArg, "error", err)
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		singleCommit = hash
+	} else if revisionRange != "" {
+		if err := core.ValidateRevisionRange(repoRoot, revisionRange); err != nil {
+			logger.Error("Invalid revision range", "range", revisionRange, "error", err)
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	
 	logger.Info("Starting TUI application", "repository", cwd)
-	if err := tui.RunApp(); err != nil {
+	if err := tui.RunApp(tui.Options{Range: revisionRange, Commit: singleCommit, DryRun: *dryRun}); err != nil {
 		logger.Error("TUI application error", "error", err)
 		fmt.Printf("Error: %v", err)
 		os.Exit(1)